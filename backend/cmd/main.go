@@ -42,17 +42,39 @@ func main() {
 	}))
 
 	l.Info("PostgreSQL initializing")
-	postgresDB, shutdown, err := pgclient.InitDB(notifyCtx, l, &cfg.Postgres)
-	if err != nil {
+	connections := service.NewConnections(connector(l), &cfg.App, cfg.DefaultProfile())
+	for _, profile := range cfg.Profiles() {
+		if err = connections.Register(profile); err != nil {
+			l.Error("Failed to register connection", "id", profile.ID, logger.ErrAttr(err))
+
+			return
+		}
+	}
+
+	shutdowns = append(shutdowns, connections.Close)
+
+	dbService := service.NewDBService(connections, &cfg.App)
+
+	// Profiles connect on first use, so migrate only connects the profile it migrates.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err = runMigrate(notifyCtx, dbService, os.Args[2:]); err != nil {
+			l.Error("Migration failed", logger.ErrAttr(err))
+			closeConnections(shutdowns, l, cfg.App.ShutdownTimeout)
+			os.Exit(1) //nolint:gocritic // connections are closed above, CI needs a non-zero exit code
+		}
+
+		return
+	}
+
+	if err = connections.Connect(notifyCtx, ""); err != nil {
 		l.Error("Failed to initialize postgresSQL", logger.ErrAttr(err))
 
 		return
 	}
 
-	shutdowns = append(shutdowns, shutdown)
+	go dbService.RunStatementSnapshots(notifyCtx, l)
+	go dbService.RunJobs(notifyCtx, l)
 
-	db := repository.NewDB(postgresDB, &cfg.Postgres)
-	dbService := service.NewDBService(db, &cfg.App)
 	handler := rest.NewHandler(dbService, l)
 
 	appServer, shutdown := rest.NewServer(l, &cfg.AppServer, handler)
@@ -73,6 +95,18 @@ func main() {
 	l.Info("Termination signal received, shutting down...")
 }
 
+// connector opens a pool for a connection profile and wraps it into a repository.
+func connector(l *slog.Logger) service.Connector {
+	return func(ctx context.Context, cfg *config.PostgresConfig) (service.Repository, func(context.Context) error, error) {
+		postgresDB, shutdown, err := pgclient.InitDB(ctx, l, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return repository.NewDB(postgresDB, cfg), shutdown, nil
+	}
+}
+
 func closeConnections(shutdowns []func(context.Context) error, l *slog.Logger, timeout time.Duration) {
 	l.Warn("Closing connections")
 
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"l6/internal/domain"
+	"l6/internal/service"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+const migrateUsage = "usage: migrate [-connection id] [-to version] status|up|down"
+
+// runMigrate runs the migrate subcommand so that CI can apply migrations
+// without starting the HTTP server.
+func runMigrate(ctx context.Context, svc *service.Service, args []string) error {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	connectionID := flags.String("connection", "", "connection profile, the default one when empty")
+	target := flags.String("to", "", "target version")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	var to *int64
+	if *target != "" {
+		version, err := strconv.ParseInt(*target, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid target version %q: %w", *target, err)
+		}
+		to = &version
+	}
+
+	if *connectionID != "" {
+		ctx = domain.ContextWithConnection(ctx, *connectionID)
+	}
+
+	var (
+		run domain.MigrationRun
+		err error
+	)
+	switch flags.Arg(0) {
+	case "status":
+		return printMigrations(ctx, svc)
+	case "up":
+		run, err = svc.MigrateUp(ctx, to)
+	case "down":
+		run, err = svc.MigrateDown(ctx, to)
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	// A failed run still reports the migrations executed before the failure.
+	for _, m := range run.Executed {
+		fmt.Fprintf(os.Stdout, "%s %d_%s (%d ms)\n", m.Direction, m.Version, m.Name, m.DurationMs)
+	}
+	if run.Message != "" {
+		fmt.Fprintln(os.Stdout, run.Message)
+	}
+
+	return err
+}
+
+func printMigrations(ctx context.Context, svc *service.Service) error {
+	migrations, err := svc.MigrationStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, m := range migrations {
+		status := "pending"
+		switch {
+		case m.Missing:
+			status = "missing"
+		case m.Modified:
+			status = "modified"
+		case m.Applied:
+			status = "applied"
+		}
+
+		appliedAt := ""
+		if m.AppliedAt != nil {
+			appliedAt = m.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", m.Version, m.Name, status, appliedAt)
+	}
+
+	return w.Flush()
+}
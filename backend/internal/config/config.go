@@ -4,21 +4,68 @@ import (
 	"fmt"
 	"l6/pkg/logger"
 	"log/slog"
+	"path/filepath"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// DefaultConnectionID is the ID under which the top-level postgres section is registered.
+const DefaultConnectionID = "default"
+
 type Config struct {
-	App       AppConfig       `yaml:"app"`
-	Postgres  PostgresConfig  `yaml:"postgres"`
-	AppServer AppServerConfig `yaml:"appServer"`
+	App         AppConfig          `yaml:"app"`
+	Postgres    PostgresConfig     `yaml:"postgres"`
+	Connections []ConnectionConfig `yaml:"connections"`
+	AppServer   AppServerConfig    `yaml:"appServer"`
 }
 
 type AppConfig struct {
-	LogLevel        string        `env:"APP_LOG_LEVEL"          yaml:"logLevel"`
-	ShutdownTimeout time.Duration `env:"APP_SHUTDOWN_TIMEOUT"   yaml:"shutdownTimeout"`
-	BackupDir       string        `env:"APP_BACKUP_DIR"         yaml:"backupDir"`
+	LogLevel          string           `env:"APP_LOG_LEVEL"          yaml:"logLevel"`
+	ShutdownTimeout   time.Duration    `env:"APP_SHUTDOWN_TIMEOUT"   yaml:"shutdownTimeout"`
+	BackupDir         string           `env:"APP_BACKUP_DIR"         yaml:"backupDir"`
+	DefaultConnection string           `env:"APP_DEFAULT_CONNECTION" yaml:"defaultConnection"`
+	WipeTokenTTL      time.Duration    `env:"APP_WIPE_TOKEN_TTL"     yaml:"wipeTokenTTL"`
+	MigrationsDir     string           `env:"APP_MIGRATIONS_DIR"     yaml:"migrationsDir"`
+	Health            HealthConfig     `yaml:"health"`
+	Statements        StatementsConfig `yaml:"statements"`
+	Jobs              JobsConfig       `yaml:"jobs"`
+}
+
+// JobsConfig controls the background backup, restore, wipe and maintenance jobs. Zero values fall
+// back to the defaults of the service.
+type JobsConfig struct {
+	Workers   int           `env:"APP_JOBS_WORKERS"   yaml:"workers"`
+	Queue     int           `env:"APP_JOBS_QUEUE"     yaml:"queue"`
+	Retention time.Duration `env:"APP_JOBS_RETENTION" yaml:"retention"`
+}
+
+// StatementsConfig controls the pg_stat_statements snapshots kept by the service.
+type StatementsConfig struct {
+	SnapshotInterval time.Duration `env:"APP_STATEMENTS_SNAPSHOT_INTERVAL" yaml:"snapshotInterval"`
+	Retention        time.Duration `env:"APP_STATEMENTS_RETENTION"         yaml:"retention"`
+	MaxStatements    int           `env:"APP_STATEMENTS_MAX_STATEMENTS"    yaml:"maxStatements"`
+}
+
+// HealthConfig holds the warning thresholds of the database health report.
+// Zero values fall back to the defaults of the service.
+type HealthConfig struct {
+	CacheHitRatio      float64       `env:"APP_HEALTH_CACHE_HIT_RATIO"      yaml:"cacheHitRatio"`
+	ConnectionsPercent float64       `env:"APP_HEALTH_CONNECTIONS_PERCENT"  yaml:"connectionsPercent"`
+	ReplicationLag     time.Duration `env:"APP_HEALTH_REPLICATION_LAG"      yaml:"replicationLag"`
+	OldestXact         time.Duration `env:"APP_HEALTH_OLDEST_XACT"          yaml:"oldestXact"`
+	WraparoundPercent  float64       `env:"APP_HEALTH_WRAPAROUND_PERCENT"   yaml:"wraparoundPercent"`
+	DeadTupleRatio     float64       `env:"APP_HEALTH_DEAD_TUPLE_RATIO"     yaml:"deadTupleRatio"`
+	LongQuery          time.Duration `env:"APP_HEALTH_LONG_QUERY"           yaml:"longQuery"`
+	SlotRetainedWAL    int64         `env:"APP_HEALTH_SLOT_RETAINED_WAL"    yaml:"slotRetainedWAL"`
+}
+
+// ConnectionConfig describes one database profile of the connection registry.
+type ConnectionConfig struct {
+	ID        string         `yaml:"id"`
+	Name      string         `yaml:"name"`
+	BackupDir string         `yaml:"backupDir"`
+	Postgres  PostgresConfig `yaml:"postgres"`
 }
 
 type PostgresConfig struct {
@@ -30,6 +77,8 @@ type PostgresConfig struct {
 	ConnectionAttempts int           `env:"POSTGRES_CONNECTION_ATTEMPTS" yaml:"connectionAttempts"`
 	DelayBtwAttempts   time.Duration `env:"POSTGRES_DELAY_BTW_ATTEMPTS"  yaml:"delayBtwAttempts"`
 	QueryTimeout       time.Duration `env:"POSTGRES_QUERY_TIMEOUT"       yaml:"queryTimeout"`
+	MaxOpenConns       int           `env:"POSTGRES_MAX_OPEN_CONNS"      yaml:"maxOpenConns"`
+	MaxIdleConns       int           `env:"POSTGRES_MAX_IDLE_CONNS"      yaml:"maxIdleConns"`
 }
 
 type AppServerConfig struct {
@@ -58,6 +107,39 @@ func LoadConfig(l *slog.Logger) (*Config, error) {
 	return cfg, nil
 }
 
+// Profiles returns every connection profile defined in the config. The top-level
+// postgres section is registered as DefaultConnectionID when it has a host.
+func (c Config) Profiles() []ConnectionConfig {
+	profiles := make([]ConnectionConfig, 0, len(c.Connections)+1)
+
+	if c.Postgres.Host != "" {
+		profiles = append(profiles, ConnectionConfig{
+			ID:        DefaultConnectionID,
+			Name:      c.Postgres.Database,
+			BackupDir: c.App.BackupDir,
+			Postgres:  c.Postgres,
+		})
+	}
+
+	for _, conn := range c.Connections {
+		if conn.BackupDir == "" {
+			conn.BackupDir = filepath.Join(c.App.BackupDir, conn.ID)
+		}
+		profiles = append(profiles, conn)
+	}
+
+	return profiles
+}
+
+// DefaultProfile returns the ID of the profile used when a request names none.
+func (c Config) DefaultProfile() string {
+	if c.App.DefaultConnection != "" {
+		return c.App.DefaultConnection
+	}
+
+	return DefaultConnectionID
+}
+
 func (c Config) Level() slog.Level {
 	switch c.App.LogLevel {
 	case "debug":
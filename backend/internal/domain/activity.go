@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Session is a backend from pg_stat_activity. DurationMs is the time since the
+// current query started and XactAgeMs the age of the open transaction.
+type Session struct {
+	PID           int        `db:"pid"              json:"pid"`
+	User          *string    `db:"user_name"        json:"user"`
+	Database      *string    `db:"database"         json:"database"`
+	Application   string     `db:"application_name" json:"application"`
+	Client        *string    `db:"client"           json:"client"`
+	BackendType   string     `db:"backend_type"     json:"backend_type"`
+	State         *string    `db:"state"            json:"state"`
+	WaitEventType *string    `db:"wait_event_type"  json:"wait_event_type"`
+	WaitEvent     *string    `db:"wait_event"       json:"wait_event"`
+	Query         string     `db:"query"            json:"query"`
+	BackendStart  *time.Time `db:"backend_start"    json:"backend_start"`
+	QueryStart    *time.Time `db:"query_start"      json:"query_start"`
+	DurationMs    *int64     `db:"duration_ms"      json:"duration_ms"`
+	XactAgeMs     *int64     `db:"xact_age_ms"      json:"xact_age_ms"`
+	BlockedBy     []int      `db:"-"                json:"blocked_by"`
+}
+
+// LockWait is the lock a blocked session waits for.
+type LockWait struct {
+	LockType string  `db:"lock_type" json:"lock_type"`
+	Mode     string  `db:"mode"      json:"mode"`
+	Relation *string `db:"relation"  json:"relation"`
+}
+
+// LockNode is a session in the blocking tree with the sessions waiting on it.
+type LockNode struct {
+	Session
+	WaitingFor *LockWait  `json:"waiting_for,omitempty"`
+	Blocks     []LockNode `json:"blocks"`
+}
+
+// LockTree lists the sessions blocking others at the top with the sessions they
+// block below them. Blocked counts the sessions waiting for a lock.
+type LockTree struct {
+	Roots   []LockNode `json:"roots"`
+	Blocked int        `json:"blocked"`
+}
+
+type SessionSignaled struct {
+	PID     int    `json:"pid"`
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+}
@@ -2,18 +2,95 @@ package domain
 
 import "time"
 
+// Output formats of pg_dump. They match the formats pgclient.DetectDumpFormat
+// reports.
+const (
+	BackupPlain     = "plain"
+	BackupCustom    = "custom"
+	BackupDirectory = "directory"
+	BackupTar       = "tar"
+)
+
+// BackupMetaSuffix is appended to a backup filename to name its sidecar metadata file.
+const BackupMetaSuffix = ".meta.json"
+
+// Backup is a backup file. Options are read from the sidecar metadata file and are
+// nil for backups taken without one.
 type Backup struct {
-	Filename  string    `json:"filename"`
-	CreatedAt time.Time `json:"created_at"`
-	Size      int64     `json:"size"`
+	Filename  string         `json:"filename"`
+	Format    string         `json:"format"`
+	CreatedAt time.Time      `json:"created_at"`
+	Size      int64          `json:"size"`
+	Options   *BackupOptions `json:"options"`
+}
+
+// BackupOptions select the pg_dump output format and contents. Jobs dumps tables
+// in parallel and is only supported by the directory format. Tables and schemas
+// are pg_dump patterns. Clean adds DROP ... IF EXISTS statements to plain dumps;
+// archive formats take it at restore time instead.
+type BackupOptions struct {
+	Format         string   `json:"format"`
+	Jobs           int      `json:"jobs"`
+	SchemaOnly     bool     `json:"schema_only"`
+	DataOnly       bool     `json:"data_only"`
+	Tables         []string `json:"tables"`
+	ExcludeTables  []string `json:"exclude_tables"`
+	Schemas        []string `json:"schemas"`
+	ExcludeSchemas []string `json:"exclude_schemas"`
+	NoOwner        bool     `json:"no_owner"`
+	NoPrivileges   bool     `json:"no_privileges"`
+	Clean          bool     `json:"clean"`
+}
+
+// BackupMeta is the content of the sidecar metadata file of a backup.
+type BackupMeta struct {
+	Database  string        `json:"database"`
+	CreatedAt time.Time     `json:"created_at"`
+	Options   BackupOptions `json:"options"`
 }
 
 type BackupCreated struct {
 	Filename string `json:"filename"`
+	Format   string `json:"format"`
 	Message  string `json:"message"`
 	Success  bool   `json:"success"`
 }
 
+// ArchiveEntry is an entry of the table of contents of an archive backup as
+// listed by pg_restore -l. Schema is empty for objects outside a schema.
+type ArchiveEntry struct {
+	ID     int    `json:"id"`
+	Type   string `json:"type"`
+	Schema string `json:"schema"`
+	Name   string `json:"name"`
+	Owner  string `json:"owner"`
+}
+
+// BackupContents lists the entries of an archive backup.
+type BackupContents struct {
+	Filename string         `json:"filename"`
+	Format   string         `json:"format"`
+	Entries  []ArchiveEntry `json:"entries"`
+}
+
+// RestoreOptions select what to restore from an archive backup and where. Schemas
+// and Tables limit the restore by name, Types to archive entry types such as TABLE
+// or TABLE DATA. TargetSchema restores the objects of the single selected schema
+// under another name. ExitOnError stops at the first failing statement instead of
+// reporting the errors at the end. Empty fields restore the whole backup into the
+// connection's database.
+type RestoreOptions struct {
+	Schemas           []string `json:"schemas"`
+	Tables            []string `json:"tables"`
+	Types             []string `json:"types"`
+	TargetDatabase    string   `json:"target_database"`
+	TargetSchema      string   `json:"target_schema"`
+	Clean             bool     `json:"clean"`
+	SingleTransaction bool     `json:"single_transaction"`
+	Jobs              int      `json:"jobs"`
+	ExitOnError       bool     `json:"exit_on_error"`
+}
+
 type BackupDeleted struct {
 	Message string `json:"message"`
 	Success bool   `json:"success"`
@@ -0,0 +1,37 @@
+package domain
+
+import "context"
+
+type Connection struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Host      string `json:"host"`
+	Port      string `json:"port"`
+	Database  string `json:"database"`
+	Username  string `json:"username"`
+	BackupDir string `json:"backup_dir"`
+	Default   bool   `json:"default"`
+	Connected bool   `json:"connected"`
+}
+
+type ConnectionTested struct {
+	Message   string `json:"message"`
+	Success   bool   `json:"success"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type connectionKey struct{}
+
+// ContextWithConnection stores the ID of the connection profile a request works with.
+func ContextWithConnection(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, connectionKey{}, id)
+}
+
+// ConnectionFromContext returns the connection ID stored in ctx or an empty string.
+func ConnectionFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(connectionKey{}).(string); ok {
+		return id
+	}
+
+	return ""
+}
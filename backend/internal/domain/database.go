@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+type Database struct {
+	Name             string `db:"name"              json:"name"`
+	Owner            string `db:"owner"             json:"owner"`
+	Encoding         string `db:"encoding"          json:"encoding"`
+	Collation        string `db:"collation"         json:"collation"`
+	Ctype            string `db:"ctype"             json:"ctype"`
+	ConnectionLimit  int    `db:"connection_limit"  json:"connection_limit"`
+	SizeBytes        *int64 `db:"size_bytes"        json:"size_bytes"`
+	IsTemplate       bool   `db:"is_template"       json:"is_template"`
+	AllowConnections bool   `db:"allow_connections" json:"allow_connections"`
+	Current          bool   `db:"current"           json:"current"`
+}
+
+// DatabaseDropPreview describes the database DropDatabase would remove together
+// with the confirmation token required to drop it.
+type DatabaseDropPreview struct {
+	Database  Database  `json:"database"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DatabaseCreate describes a new database. Empty fields fall back to the server defaults.
+type DatabaseCreate struct {
+	Name            string `json:"name"`
+	Template        string `json:"template"`
+	Owner           string `json:"owner"`
+	Encoding        string `json:"encoding"`
+	Collation       string `json:"collation"`
+	Ctype           string `json:"ctype"`
+	ConnectionLimit *int   `json:"connection_limit"`
+}
@@ -0,0 +1,78 @@
+package domain
+
+// ColumnDefinition describes a table column. Type, Default and USING expressions
+// are SQL fragments and are embedded as is, names are always quoted.
+type ColumnDefinition struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Default  *string `json:"default,omitempty"`
+	NotNull  bool    `json:"not_null"`
+	Identity string  `json:"identity,omitempty"` // "always" or "by_default"
+	Comment  *string `json:"comment,omitempty"`
+}
+
+// ForeignKeyReference is the referenced side of a foreign key constraint.
+type ForeignKeyReference struct {
+	Schema   string   `json:"schema"`
+	Table    string   `json:"table"`
+	Columns  []string `json:"columns"`
+	OnDelete string   `json:"on_delete,omitempty"`
+	OnUpdate string   `json:"on_update,omitempty"`
+}
+
+// ConstraintDefinition describes a table constraint. Type is one of
+// primary_key, unique, check or foreign_key.
+type ConstraintDefinition struct {
+	Name       string               `json:"name,omitempty"`
+	Type       string               `json:"type"`
+	Columns    []string             `json:"columns,omitempty"`
+	Check      string               `json:"check,omitempty"`
+	References *ForeignKeyReference `json:"references,omitempty"`
+}
+
+// TableDefinition describes a new table.
+type TableDefinition struct {
+	Schema      string                 `json:"schema"`
+	Name        string                 `json:"name"`
+	Columns     []ColumnDefinition     `json:"columns"`
+	Constraints []ConstraintDefinition `json:"constraints"`
+	Comment     *string                `json:"comment,omitempty"`
+}
+
+type ColumnRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ColumnAlteration changes an existing column. Nil fields are left untouched.
+type ColumnAlteration struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type,omitempty"`
+	Using       string  `json:"using,omitempty"`
+	Default     *string `json:"default,omitempty"`
+	DropDefault bool    `json:"drop_default"`
+	NotNull     *bool   `json:"not_null,omitempty"`
+	Comment     *string `json:"comment,omitempty"`
+}
+
+// TableAlteration lists the changes to apply to an existing table. They are
+// generated in the order drops, renames, additions, alterations, table rename.
+type TableAlteration struct {
+	Schema          string                 `json:"schema"`
+	DropConstraints []string               `json:"drop_constraints"`
+	DropColumns     []string               `json:"drop_columns"`
+	RenameColumns   []ColumnRename         `json:"rename_columns"`
+	AddColumns      []ColumnDefinition     `json:"add_columns"`
+	AlterColumns    []ColumnAlteration     `json:"alter_columns"`
+	AddConstraints  []ConstraintDefinition `json:"add_constraints"`
+	Comment         *string                `json:"comment,omitempty"`
+	RenameTo        string                 `json:"rename_to,omitempty"`
+	Cascade         bool                   `json:"cascade"`
+}
+
+// DDLResult is the generated DDL and whether it was applied.
+type DDLResult struct {
+	Statements []string `json:"statements"`
+	SQL        string   `json:"sql"`
+	Applied    bool     `json:"applied"`
+}
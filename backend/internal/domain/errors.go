@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var (
+	ErrConnectionNotFound = errors.New("connection not found")
+	ErrConnectionExists   = errors.New("connection already exists")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrConflict           = errors.New("conflict")
+	ErrNotFound           = errors.New("not found")
+)
@@ -0,0 +1,31 @@
+package domain
+
+// Extension is an extension available to the server. InstalledVersion and Schema
+// are nil when it is not installed in the current database.
+type Extension struct {
+	Name             string   `db:"name"              json:"name"`
+	DefaultVersion   *string  `db:"default_version"   json:"default_version"`
+	InstalledVersion *string  `db:"installed_version" json:"installed_version"`
+	Versions         []string `db:"-"                 json:"versions"`
+	Schema           *string  `db:"schema"            json:"schema"`
+	Relocatable      bool     `db:"relocatable"       json:"relocatable"`
+	UpdateAvailable  bool     `db:"update_available"  json:"update_available"`
+	Comment          *string  `db:"comment"           json:"comment"`
+}
+
+// ExtensionCreate installs an extension. Empty Schema and Version use the
+// extension's defaults; Cascade also installs the extensions it requires.
+type ExtensionCreate struct {
+	Name    string `json:"name"`
+	Schema  string `json:"schema"`
+	Version string `json:"version"`
+	Cascade bool   `json:"cascade"`
+}
+
+// ExtensionAlter updates an extension to Version, the default version when
+// Update is set without one, and moves it to Schema. Empty fields are left unchanged.
+type ExtensionAlter struct {
+	Update  bool   `json:"update"`
+	Version string `json:"version"`
+	Schema  string `json:"schema"`
+}
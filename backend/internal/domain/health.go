@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+const (
+	HealthOK      = "ok"
+	HealthWarning = "warning"
+	HealthUnknown = "unknown"
+)
+
+// HealthStats are the raw figures the health report is computed from.
+type HealthStats struct {
+	SampledAt          time.Time         `db:"sampled_at"`
+	Database           string            `db:"database"`
+	BlocksHit          int64             `db:"blocks_hit"`
+	BlocksRead         int64             `db:"blocks_read"`
+	Commits            int64             `db:"commits"`
+	Rollbacks          int64             `db:"rollbacks"`
+	Connections        int               `db:"connections"`
+	MaxConnections     int               `db:"max_connections"`
+	InRecovery         bool              `db:"in_recovery"`
+	Replicas           int               `db:"replicas"`
+	ReplicationLagSecs *float64          `db:"replication_lag_seconds"`
+	OldestXactSecs     *float64          `db:"oldest_xact_seconds"`
+	FrozenXidDatabase  string            `db:"frozen_xid_database"`
+	FrozenXidAge       int64             `db:"frozen_xid_age"`
+	WALBytes           *int64            `db:"wal_bytes"`
+	DeadTuples         []TableDeadTuples `db:"-"`
+	LongQueries        []Session         `db:"-"`
+}
+
+type TableDeadTuples struct {
+	Schema string  `db:"schema"     json:"schema"`
+	Table  string  `db:"table_name" json:"table"`
+	Live   int64   `db:"live"       json:"live"`
+	Dead   int64   `db:"dead"       json:"dead"`
+	Ratio  float64 `db:"ratio"      json:"ratio"`
+}
+
+// HealthCheck is one evaluated metric. Threshold is the configured warning level.
+type HealthCheck struct {
+	Name      string   `json:"name"`
+	Status    string   `json:"status"`
+	Value     *float64 `json:"value"`
+	Threshold float64  `json:"threshold"`
+	Message   string   `json:"message"`
+}
+
+// DatabaseHealth aggregates the health metrics of the current database. Rates are
+// computed against the previous report of the same connection and are nil on the first one.
+type DatabaseHealth struct {
+	Status                string            `json:"status"`
+	CheckedAt             time.Time         `json:"checked_at"`
+	Database              string            `json:"database"`
+	Checks                []HealthCheck     `json:"checks"`
+	CacheHitRatio         *float64          `json:"cache_hit_ratio"`
+	TransactionsPerSecond *float64          `json:"transactions_per_second"`
+	Connections           int               `json:"connections"`
+	MaxConnections        int               `json:"max_connections"`
+	InRecovery            bool              `json:"in_recovery"`
+	Replicas              int               `json:"replicas"`
+	ReplicationLagSeconds *float64          `json:"replication_lag_seconds"`
+	OldestXactSeconds     *float64          `json:"oldest_xact_seconds"`
+	WraparoundDatabase    string            `json:"wraparound_database"`
+	WraparoundAge         int64             `json:"wraparound_age"`
+	WALBytesPerSecond     *float64          `json:"wal_bytes_per_second"`
+	DeadTuples            []TableDeadTuples `json:"dead_tuples"`
+	LongRunningQueries    []Session         `json:"long_running_queries"`
+}
@@ -0,0 +1,37 @@
+package domain
+
+// Index describes an index with its usage statistics from pg_stat_user_indexes.
+// BloatBytes is an estimate and is only computed for btree indexes.
+type Index struct {
+	Schema        string   `db:"schema"         json:"schema"`
+	Table         string   `db:"table_name"     json:"table"`
+	Name          string   `db:"name"           json:"name"`
+	Definition    string   `db:"definition"     json:"definition"`
+	Method        string   `db:"method"         json:"method"`
+	Unique        bool     `db:"is_unique"      json:"unique"`
+	Primary       bool     `db:"is_primary"     json:"primary"`
+	Constraint    bool     `db:"is_constraint"  json:"constraint"`
+	Valid         bool     `db:"is_valid"       json:"valid"`
+	SizeBytes     int64    `db:"size_bytes"     json:"size_bytes"`
+	BloatBytes    *int64   `db:"bloat_bytes"    json:"bloat_bytes"`
+	Scans         int64    `db:"scans"          json:"scans"`
+	TuplesRead    int64    `db:"tuples_read"    json:"tuples_read"`
+	TuplesFetched int64    `db:"tuples_fetched" json:"tuples_fetched"`
+	Unused        bool     `db:"unused"         json:"unused"`
+	DuplicateOf   []string `db:"-"              json:"duplicate_of"`
+}
+
+// IndexCreate describes a new index. Columns are quoted, Expressions and Where
+// are SQL fragments embedded as is.
+type IndexCreate struct {
+	Schema       string   `json:"schema"`
+	Table        string   `json:"table"`
+	Name         string   `json:"name"`
+	Method       string   `json:"method"`
+	Columns      []string `json:"columns"`
+	Expressions  []string `json:"expressions"`
+	Include      []string `json:"include"`
+	Where        string   `json:"where"`
+	Unique       bool     `json:"unique"`
+	Concurrently bool     `json:"concurrently"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"sync"
+	"time"
+)
+
+// Job states.
+const (
+	JobQueued    = "queued"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+	JobCancelled = "cancelled"
+)
+
+// Job kinds.
+const (
+	JobBackup  = "backup"
+	JobRestore = "restore"
+	JobWipe    = "wipe"
+	JobVacuum  = "vacuum"
+	JobIndex   = "create_index"
+	JobReindex = "reindex"
+	JobRefresh = "refresh"
+)
+
+// Job is a backup, restore, wipe or maintenance operation running in the
+// background. Object is the table, index or view a maintenance job works on. BytesWritten is the size of the backup written so far
+// and is nil for other jobs. Stderr holds the tail of the output of the
+// client tool. Result holds what a finished job produced besides the backup file,
+// the TablesDeleted of a wipe.
+type Job struct {
+	ID             string     `json:"id"`
+	Kind           string     `json:"kind"`
+	Connection     string     `json:"connection"`
+	State          string     `json:"state"`
+	Filename       string     `json:"filename,omitempty"`
+	Object         string     `json:"object,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	StartedAt      *time.Time `json:"started_at"`
+	FinishedAt     *time.Time `json:"finished_at"`
+	ElapsedSeconds float64    `json:"elapsed_seconds"`
+	BytesWritten   *int64     `json:"bytes_written"`
+	Stderr         string     `json:"stderr"`
+	Error          string     `json:"error,omitempty"`
+	Result         any        `json:"result,omitempty"`
+}
+
+// toolOutputLimit caps the output kept by ToolProgress.
+const toolOutputLimit = 64 << 10
+
+// ToolProgress collects the progress of a pg_dump, pg_restore or psql run while it
+// is running: the path being written and the tail of the tool's stderr.
+type ToolProgress struct {
+	mu     sync.Mutex
+	path   string
+	output []byte
+}
+
+func (p *ToolProgress) Write(b []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.output = append(p.output, b...)
+	if extra := len(p.output) - toolOutputLimit; extra > 0 {
+		p.output = p.output[extra:]
+	}
+	return len(b), nil
+}
+
+// SetPath records the file or directory the tool writes to.
+func (p *ToolProgress) SetPath(path string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.path = path
+}
+
+func (p *ToolProgress) Path() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.path
+}
+
+func (p *ToolProgress) Output() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return string(p.output)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// PublicationTable is a table published by a publication.
+type PublicationTable struct {
+	Schema string `db:"schema"     json:"schema"`
+	Table  string `db:"table_name" json:"table"`
+}
+
+// Publication is a logical replication publication. Tables lists every published
+// table, also for publications of all tables.
+type Publication struct {
+	Name      string             `db:"name"       json:"name"`
+	Owner     string             `db:"owner"      json:"owner"`
+	AllTables bool               `db:"all_tables" json:"all_tables"`
+	Insert    bool               `db:"insert"     json:"insert"`
+	Update    bool               `db:"update"     json:"update"`
+	Delete    bool               `db:"delete"     json:"delete"`
+	Truncate  bool               `db:"truncate"   json:"truncate"`
+	ViaRoot   bool               `db:"via_root"   json:"via_root"`
+	Tables    []PublicationTable `db:"-"          json:"tables"`
+}
+
+// PublicationCreate describes a new publication of all tables or of Tables.
+// Operations is a subset of insert, update, delete and truncate, all by default.
+type PublicationCreate struct {
+	Name       string             `json:"name"`
+	AllTables  bool               `json:"all_tables"`
+	Tables     []PublicationTable `json:"tables"`
+	Operations []string           `json:"operations"`
+}
+
+// PublicationAlter changes a publication. Empty fields are left unchanged.
+type PublicationAlter struct {
+	AddTables  []PublicationTable `json:"add_tables"`
+	DropTables []PublicationTable `json:"drop_tables"`
+	Operations []string           `json:"operations"`
+	RenameTo   string             `json:"rename_to"`
+}
+
+// Subscription is a logical replication subscription of the current database with
+// the state of its apply worker from pg_stat_subscription. TablesSyncing counts the
+// tables whose initial copy is not finished.
+type Subscription struct {
+	Name          string     `db:"name"                  json:"name"`
+	Owner         string     `db:"owner"                 json:"owner"`
+	Enabled       bool       `db:"enabled"               json:"enabled"`
+	Publications  []string   `db:"-"                     json:"publications"`
+	SlotName      *string    `db:"slot_name"             json:"slot_name"`
+	WorkerPid     *int       `db:"worker_pid"            json:"worker_pid"`
+	ReceivedLSN   *string    `db:"received_lsn"          json:"received_lsn"`
+	LatestEndLSN  *string    `db:"latest_end_lsn"        json:"latest_end_lsn"`
+	LastMessageAt *time.Time `db:"last_msg_receipt_time" json:"last_message_at"`
+	LatestEndAt   *time.Time `db:"latest_end_time"       json:"latest_end_at"`
+	Tables        int        `db:"tables"                json:"tables"`
+	TablesSyncing int        `db:"tables_syncing"        json:"tables_syncing"`
+}
+
+// SubscriptionCreate describes a new subscription. Nil Enabled and CopyData use
+// the server defaults.
+type SubscriptionCreate struct {
+	Name         string   `json:"name"`
+	ConnInfo     string   `json:"conninfo"`
+	Publications []string `json:"publications"`
+	Enabled      *bool    `json:"enabled,omitempty"`
+	CopyData     *bool    `json:"copy_data,omitempty"`
+}
+
+// SubscriptionAlter changes a subscription. Nil or empty fields are left unchanged.
+type SubscriptionAlter struct {
+	Enabled      *bool    `json:"enabled,omitempty"`
+	Publications []string `json:"publications"`
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// Migration is the status of a migration known from the migrations directory
+// or from the tracking table.
+type Migration struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Checksum  string     `json:"checksum"`
+	HasDown   bool       `json:"has_down"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Modified  bool       `json:"modified"`
+	Missing   bool       `json:"missing"`
+}
+
+// AppliedMigration is a row of the migrations tracking table.
+type AppliedMigration struct {
+	Version   int64     `db:"version"    json:"version"`
+	Name      string    `db:"name"       json:"name"`
+	Checksum  string    `db:"checksum"   json:"checksum"`
+	AppliedAt time.Time `db:"applied_at" json:"applied_at"`
+}
+
+// MigrationStep is a single migration to apply (up) or roll back (down).
+type MigrationStep struct {
+	Version  int64
+	Name     string
+	Checksum string
+	SQL      string
+	Down     bool
+}
+
+type MigrationExecuted struct {
+	Version    int64  `json:"version"`
+	Name       string `json:"name"`
+	Direction  string `json:"direction"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type MigrationRun struct {
+	Executed []MigrationExecuted `json:"executed"`
+	Message  string              `json:"message"`
+	Success  bool                `json:"success"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+// View describes a view or a materialized view. Definition is only filled when
+// a single view is requested.
+type View struct {
+	Schema       string  `db:"schema"       json:"schema"`
+	Name         string  `db:"name"         json:"name"`
+	Materialized bool    `db:"materialized" json:"materialized"`
+	Owner        string  `db:"owner"        json:"owner"`
+	Populated    bool    `db:"populated"    json:"populated"`
+	SizeBytes    int64   `db:"size_bytes"   json:"size_bytes"`
+	Comment      *string `db:"comment"      json:"comment"`
+	Definition   string  `db:"definition"   json:"definition,omitempty"`
+}
+
+// Function describes a function, procedure, aggregate or window function.
+// Source and Definition are only filled when a single routine is requested.
+type Function struct {
+	Schema          string  `db:"schema"           json:"schema"`
+	Name            string  `db:"name"             json:"name"`
+	Kind            string  `db:"kind"             json:"kind"`
+	Arguments       string  `db:"arguments"        json:"arguments"`
+	Result          *string `db:"result"           json:"result"`
+	Language        string  `db:"language"         json:"language"`
+	Volatility      string  `db:"volatility"       json:"volatility"`
+	Strict          bool    `db:"strict"           json:"strict"`
+	SecurityDefiner bool    `db:"security_definer" json:"security_definer"`
+	Owner           string  `db:"owner"            json:"owner"`
+	Comment         *string `db:"comment"          json:"comment"`
+	Source          string  `db:"source"           json:"source,omitempty"`
+	Definition      string  `db:"definition"       json:"definition,omitempty"`
+}
+
+type Trigger struct {
+	Schema     string `db:"schema"     json:"schema"`
+	Table      string `db:"table_name" json:"table"`
+	Name       string `db:"name"       json:"name"`
+	Timing     string `db:"timing"     json:"timing"`
+	Events     string `db:"events"     json:"events"`
+	Level      string `db:"level"      json:"level"`
+	Function   string `db:"function"   json:"function"`
+	Enabled    string `db:"enabled"    json:"enabled"`
+	Definition string `db:"definition" json:"definition"`
+}
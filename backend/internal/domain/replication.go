@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// Replica is a WAL sender from pg_stat_replication. ReplayLagBytes is the distance
+// between the current WAL position of the server and the replica's replay position.
+type Replica struct {
+	Pid              int       `db:"pid"                json:"pid"`
+	User             *string   `db:"user_name"          json:"user"`
+	ApplicationName  string    `db:"application_name"   json:"application_name"`
+	ClientAddr       *string   `db:"client_addr"        json:"client_addr"`
+	State            *string   `db:"state"              json:"state"`
+	SyncState        *string   `db:"sync_state"         json:"sync_state"`
+	SentLSN          *string   `db:"sent_lsn"           json:"sent_lsn"`
+	WriteLSN         *string   `db:"write_lsn"          json:"write_lsn"`
+	FlushLSN         *string   `db:"flush_lsn"          json:"flush_lsn"`
+	ReplayLSN        *string   `db:"replay_lsn"         json:"replay_lsn"`
+	WriteLagSeconds  *float64  `db:"write_lag_seconds"  json:"write_lag_seconds"`
+	FlushLagSeconds  *float64  `db:"flush_lag_seconds"  json:"flush_lag_seconds"`
+	ReplayLagSeconds *float64  `db:"replay_lag_seconds" json:"replay_lag_seconds"`
+	ReplayLagBytes   *int64    `db:"replay_lag_bytes"   json:"replay_lag_bytes"`
+	BackendStart     time.Time `db:"backend_start"      json:"backend_start"`
+}
+
+// ReplicationSlot is a slot from pg_replication_slots. RetainedWALBytes is the WAL
+// kept on the server because of the slot.
+type ReplicationSlot struct {
+	Name              string  `db:"name"                json:"name"`
+	Type              string  `db:"slot_type"           json:"type"`
+	Plugin            *string `db:"plugin"              json:"plugin"`
+	Database          *string `db:"database"            json:"database"`
+	Temporary         bool    `db:"temporary"           json:"temporary"`
+	Active            bool    `db:"active"              json:"active"`
+	ActivePid         *int    `db:"active_pid"          json:"active_pid"`
+	RestartLSN        *string `db:"restart_lsn"         json:"restart_lsn"`
+	ConfirmedFlushLSN *string `db:"confirmed_flush_lsn" json:"confirmed_flush_lsn"`
+	WALStatus         *string `db:"wal_status"          json:"wal_status"`
+	RetainedWALBytes  *int64  `db:"retained_wal_bytes"  json:"retained_wal_bytes"`
+}
+
+// WALReceiver is the WAL receiver of a standby from pg_stat_wal_receiver.
+// ReplayDelaySeconds is the age of the last replayed transaction.
+type WALReceiver struct {
+	Pid                int        `db:"pid"                   json:"pid"`
+	Status             string     `db:"status"                json:"status"`
+	SenderHost         *string    `db:"sender_host"           json:"sender_host"`
+	SenderPort         *int       `db:"sender_port"           json:"sender_port"`
+	SlotName           *string    `db:"slot_name"             json:"slot_name"`
+	FlushedLSN         *string    `db:"flushed_lsn"           json:"flushed_lsn"`
+	LatestEndLSN       *string    `db:"latest_end_lsn"        json:"latest_end_lsn"`
+	LastMessageAt      *time.Time `db:"last_msg_receipt_time" json:"last_message_at"`
+	ReplayDelaySeconds *float64   `db:"replay_delay_seconds"  json:"replay_delay_seconds"`
+}
+
+// ReplicationStatus describes the replication role of the connected server.
+// WALReceiver is only set on a standby that is streaming.
+type ReplicationStatus struct {
+	Role        string            `json:"role"`
+	InRecovery  bool              `json:"in_recovery"`
+	CurrentLSN  string            `json:"current_lsn"`
+	Replicas    []Replica         `json:"replicas"`
+	Slots       []ReplicationSlot `json:"slots"`
+	WALReceiver *WALReceiver      `json:"wal_receiver"`
+	Warnings    []string          `json:"warnings"`
+}
@@ -0,0 +1,47 @@
+package domain
+
+// TableRLS is the row-level security state of a table.
+type TableRLS struct {
+	Schema   string `db:"schema"     json:"schema"`
+	Table    string `db:"table_name" json:"table"`
+	Enabled  bool   `db:"enabled"    json:"enabled"`
+	Forced   bool   `db:"forced"     json:"forced"`
+	Policies int    `db:"policies"   json:"policies"`
+}
+
+// Policy is a row-level security policy from pg_policies.
+type Policy struct {
+	Schema     string   `db:"schema"     json:"schema"`
+	Table      string   `db:"table_name" json:"table"`
+	Name       string   `db:"name"       json:"name"`
+	Permissive string   `db:"permissive" json:"permissive"`
+	Roles      []string `db:"-"          json:"roles"`
+	Command    string   `db:"command"    json:"command"`
+	Using      *string  `db:"using"      json:"using"`
+	WithCheck  *string  `db:"with_check" json:"with_check"`
+}
+
+// PolicyCreate describes a new policy. Command is ALL, SELECT, INSERT, UPDATE or
+// DELETE; no roles means PUBLIC. Using and WithCheck are SQL expressions embedded as is.
+type PolicyCreate struct {
+	Name        string   `json:"name"`
+	Restrictive bool     `json:"restrictive"`
+	Command     string   `json:"command"`
+	Roles       []string `json:"roles"`
+	Using       string   `json:"using"`
+	WithCheck   string   `json:"with_check"`
+}
+
+// PolicyAlter changes a policy. Nil or empty fields are left unchanged.
+type PolicyAlter struct {
+	Roles     []string `json:"roles"`
+	Using     *string  `json:"using,omitempty"`
+	WithCheck *string  `json:"with_check,omitempty"`
+	RenameTo  string   `json:"rename_to,omitempty"`
+}
+
+// RLSChange enables or forces row-level security on a table. Nil fields are left unchanged.
+type RLSChange struct {
+	Enabled *bool `json:"enabled,omitempty"`
+	Forced  *bool `json:"forced,omitempty"`
+}
@@ -0,0 +1,88 @@
+package domain
+
+import "time"
+
+type Role struct {
+	Name            string     `db:"name"             json:"name"`
+	Superuser       bool       `db:"superuser"        json:"superuser"`
+	Inherit         bool       `db:"inherit"          json:"inherit"`
+	CreateRole      bool       `db:"create_role"      json:"create_role"`
+	CreateDB        bool       `db:"create_db"        json:"create_db"`
+	Login           bool       `db:"login"            json:"login"`
+	Replication     bool       `db:"replication"      json:"replication"`
+	BypassRLS       bool       `db:"bypass_rls"       json:"bypass_rls"`
+	ConnectionLimit int        `db:"connection_limit" json:"connection_limit"`
+	ValidUntil      *time.Time `db:"valid_until"      json:"valid_until"`
+	MemberOf        []string   `db:"-"                json:"member_of"`
+	Members         []string   `db:"-"                json:"members"`
+}
+
+// RoleAttributes are the options of CREATE ROLE and ALTER ROLE. Nil fields are left
+// at their defaults or unchanged.
+type RoleAttributes struct {
+	Superuser       *bool   `json:"superuser,omitempty"`
+	Inherit         *bool   `json:"inherit,omitempty"`
+	CreateRole      *bool   `json:"create_role,omitempty"`
+	CreateDB        *bool   `json:"create_db,omitempty"`
+	Login           *bool   `json:"login,omitempty"`
+	Replication     *bool   `json:"replication,omitempty"`
+	BypassRLS       *bool   `json:"bypass_rls,omitempty"`
+	ConnectionLimit *int    `json:"connection_limit,omitempty"`
+	ValidUntil      *string `json:"valid_until,omitempty"`
+}
+
+type RoleCreate struct {
+	Name     string `json:"name"`
+	Password string `json:"password,omitempty"`
+	RoleAttributes
+	InRoles []string `json:"in_roles"`
+}
+
+// RoleAlter changes the attributes and memberships of a role and optionally renames it.
+type RoleAlter struct {
+	RoleAttributes
+	GrantRoles  []string `json:"grant_roles"`
+	RevokeRoles []string `json:"revoke_roles"`
+	RenameTo    string   `json:"rename_to,omitempty"`
+}
+
+// PrivilegeChange grants or revokes privileges on schemas, tables or sequences.
+// ObjectType is schema, table or sequence. For tables and sequences an empty
+// Objects list means all of them in Schema.
+type PrivilegeChange struct {
+	ObjectType  string   `json:"object_type"`
+	Schema      string   `json:"schema"`
+	Objects     []string `json:"objects"`
+	Privileges  []string `json:"privileges"`
+	Roles       []string `json:"roles"`
+	GrantOption bool     `json:"grant_option"`
+	Cascade     bool     `json:"cascade"`
+}
+
+// PrivilegeMatrix lists objects with the privileges every grantee holds on them.
+type PrivilegeMatrix struct {
+	Grantees []string          `json:"grantees"`
+	Objects  []PrivilegeObject `json:"objects"`
+}
+
+// PrivilegeObject maps grantees to their privileges on an object. Grantable lists
+// the privileges granted WITH GRANT OPTION.
+type PrivilegeObject struct {
+	ObjectType string              `json:"object_type"`
+	Schema     string              `json:"schema"`
+	Name       string              `json:"name"`
+	Owner      string              `json:"owner"`
+	Privileges map[string][]string `json:"privileges"`
+	Grantable  map[string][]string `json:"grantable"`
+}
+
+// PrivilegeGrant is a single grantee's privileges on an object.
+type PrivilegeGrant struct {
+	ObjectType string   `json:"object_type"`
+	Schema     string   `json:"schema"`
+	Name       string   `json:"name"`
+	Owner      string   `json:"owner"`
+	Grantee    string   `json:"grantee"`
+	Privileges []string `json:"privileges"`
+	Grantable  []string `json:"grantable"`
+}
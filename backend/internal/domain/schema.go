@@ -0,0 +1,112 @@
+package domain
+
+import "time"
+
+// SchemaSnapshot is a structural description of the user schemas of a database.
+type SchemaSnapshot struct {
+	Connection string             `json:"connection"`
+	Database   string             `json:"database"`
+	TakenAt    time.Time          `json:"taken_at"`
+	Tables     []SnapshotTable    `json:"tables"`
+	Views      []SnapshotView     `json:"views"`
+	Functions  []SnapshotFunction `json:"functions"`
+	Sequences  []SnapshotSequence `json:"sequences"`
+	Enums      []SnapshotEnum     `json:"enums"`
+	Triggers   []SnapshotTrigger  `json:"triggers"`
+}
+
+// SnapshotTable describes a table. Partitions carry their parent and bound instead of columns.
+type SnapshotTable struct {
+	Schema         string               `db:"schema"          json:"schema"`
+	Name           string               `db:"name"            json:"name"`
+	PartitionKey   *string              `db:"partition_key"   json:"partition_key,omitempty"`
+	ParentSchema   *string              `db:"parent_schema"   json:"parent_schema,omitempty"`
+	Parent         *string              `db:"parent"          json:"parent,omitempty"`
+	PartitionBound *string              `db:"partition_bound" json:"partition_bound,omitempty"`
+	Columns        []SnapshotColumn     `db:"-"               json:"columns"`
+	Constraints    []SnapshotConstraint `db:"-"               json:"constraints"`
+	Indexes        []SnapshotIndex      `db:"-"               json:"indexes"`
+}
+
+type SnapshotColumn struct {
+	Name      string  `db:"name"      json:"name"`
+	Type      string  `db:"type"      json:"type"`
+	Nullable  bool    `db:"nullable"  json:"nullable"`
+	Default   *string `db:"default"   json:"default,omitempty"`
+	Identity  string  `db:"identity"  json:"identity,omitempty"`
+	Generated bool    `db:"generated" json:"generated,omitempty"`
+	Position  int     `db:"position"  json:"position"`
+}
+
+// SnapshotConstraint types follow pg_constraint.contype: p, f, u, c, x.
+type SnapshotConstraint struct {
+	Name       string `db:"name"       json:"name"`
+	Type       string `db:"type"       json:"type"`
+	Definition string `db:"definition" json:"definition"`
+}
+
+// SnapshotIndex holds indexes that do not back a constraint.
+type SnapshotIndex struct {
+	Name       string `db:"name"       json:"name"`
+	Definition string `db:"definition" json:"definition"`
+}
+
+type SnapshotView struct {
+	Schema       string `db:"schema"       json:"schema"`
+	Name         string `db:"name"         json:"name"`
+	Materialized bool   `db:"materialized" json:"materialized"`
+	Definition   string `db:"definition"   json:"definition"`
+}
+
+type SnapshotFunction struct {
+	Schema     string `db:"schema"     json:"schema"`
+	Name       string `db:"name"       json:"name"`
+	Arguments  string `db:"arguments"  json:"arguments"`
+	Kind       string `db:"kind"       json:"kind"`
+	Definition string `db:"definition" json:"definition"`
+}
+
+type SnapshotSequence struct {
+	Schema    string `db:"schema"    json:"schema"`
+	Name      string `db:"name"      json:"name"`
+	DataType  string `db:"data_type" json:"data_type"`
+	Start     int64  `db:"start"     json:"start"`
+	Increment int64  `db:"increment" json:"increment"`
+	Min       int64  `db:"min"       json:"min"`
+	Max       int64  `db:"max"       json:"max"`
+	Cycle     bool   `db:"cycle"     json:"cycle"`
+}
+
+type SnapshotEnum struct {
+	Schema string   `json:"schema"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+type SnapshotTrigger struct {
+	Schema     string `db:"schema"     json:"schema"`
+	Table      string `db:"table_name" json:"table"`
+	Name       string `db:"name"       json:"name"`
+	Definition string `db:"definition" json:"definition"`
+}
+
+// SchemaSource is one side of a schema diff: either a stored snapshot or a
+// connection profile whose schema is captured on the fly.
+type SchemaSource struct {
+	Connection string          `json:"connection"`
+	Snapshot   *SchemaSnapshot `json:"snapshot"`
+}
+
+type SchemaChange struct {
+	Action string `json:"action"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// SchemaDiff describes how to turn the "from" schema into the "to" schema.
+type SchemaDiff struct {
+	Changes   []SchemaChange `json:"changes"`
+	Diff      string         `json:"diff"`
+	Migration string         `json:"migration"`
+}
@@ -0,0 +1,28 @@
+package domain
+
+// Sequence describes a sequence with the column owning it. MaxUsed is the
+// largest value stored in the owning column; Behind is set when the next value
+// of the sequence would collide with it.
+type Sequence struct {
+	Schema      string  `db:"schema"       json:"schema"`
+	Name        string  `db:"name"         json:"name"`
+	DataType    string  `db:"data_type"    json:"data_type"`
+	StartValue  int64   `db:"start_value"  json:"start_value"`
+	Increment   int64   `db:"increment"    json:"increment"`
+	LastValue   *int64  `db:"last_value"   json:"last_value"`
+	OwnerSchema *string `db:"owner_schema" json:"owner_schema"`
+	OwnerTable  *string `db:"owner_table"  json:"owner_table"`
+	OwnerColumn *string `db:"owner_column" json:"owner_column"`
+	MaxUsed     *int64  `db:"-"            json:"max_used"`
+	Behind      bool    `db:"-"            json:"behind"`
+}
+
+// SequenceResynced reports a sequence moved past the values used in its owning column.
+type SequenceResynced struct {
+	Schema    string `json:"schema"`
+	Name      string `json:"name"`
+	Table     string `json:"table"`
+	Column    string `json:"column"`
+	LastValue *int64 `json:"last_value"`
+	NextValue int64  `json:"next_value"`
+}
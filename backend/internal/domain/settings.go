@@ -0,0 +1,49 @@
+package domain
+
+// Setting is a server parameter from pg_settings. Context postmaster means the
+// parameter only changes on restart; PendingRestart is set once such a change was reloaded.
+type Setting struct {
+	Name           string   `db:"name"            json:"name"`
+	Category       string   `db:"category"        json:"category"`
+	Value          string   `db:"setting"         json:"value"`
+	Unit           *string  `db:"unit"            json:"unit"`
+	Type           string   `db:"vartype"         json:"type"`
+	Context        string   `db:"context"         json:"context"`
+	Source         string   `db:"source"          json:"source"`
+	SourceFile     *string  `db:"sourcefile"      json:"source_file"`
+	BootValue      *string  `db:"boot_val"        json:"boot_value"`
+	ResetValue     *string  `db:"reset_val"       json:"reset_value"`
+	MinValue       *string  `db:"min_val"         json:"min_value"`
+	MaxValue       *string  `db:"max_val"         json:"max_value"`
+	EnumValues     []string `db:"-"               json:"enum_values"`
+	Description    string   `db:"short_desc"      json:"description"`
+	PendingRestart bool     `db:"pending_restart" json:"pending_restart"`
+}
+
+// SettingCategory groups the settings of one pg_settings category.
+type SettingCategory struct {
+	Name     string    `json:"name"`
+	Settings []Setting `json:"settings"`
+}
+
+// PendingSetting is a value from the configuration files that differs from the
+// running one, either because it needs a restart or because it is invalid.
+type PendingSetting struct {
+	Name            string  `db:"name"             json:"name"`
+	Current         *string `db:"current"          json:"current"`
+	Pending         *string `db:"pending"          json:"pending"`
+	SourceFile      *string `db:"sourcefile"       json:"source_file"`
+	RequiresRestart bool    `db:"requires_restart" json:"requires_restart"`
+	Error           *string `db:"error"            json:"error"`
+}
+
+// SettingChange sets a parameter with ALTER SYSTEM. Value is sent as a single literal.
+type SettingChange struct {
+	Value string `json:"value"`
+}
+
+// SettingChanged reports a parameter after ALTER SYSTEM and a configuration reload.
+type SettingChanged struct {
+	Setting Setting `json:"setting"`
+	Warning string  `json:"warning,omitempty"`
+}
@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// QueryStat is a normalized statement from pg_stat_statements. In a report over
+// a time window the counters are deltas and MeanTimeMs is recomputed from them.
+// With pg_stat_statements.track = all a statement run both at top level and
+// nested in a function has a row for each.
+type QueryStat struct {
+	QueryID           int64   `db:"queryid"             json:"query_id"`
+	User              string  `db:"user_name"           json:"user"`
+	TopLevel          bool    `db:"toplevel"            json:"toplevel"`
+	Query             string  `db:"query"               json:"query"`
+	Calls             int64   `db:"calls"               json:"calls"`
+	TotalTimeMs       float64 `db:"total_time_ms"       json:"total_time_ms"`
+	MeanTimeMs        float64 `db:"mean_time_ms"        json:"mean_time_ms"`
+	Rows              int64   `db:"rows"                json:"rows"`
+	SharedBlksHit     int64   `db:"shared_blks_hit"     json:"shared_blks_hit"`
+	SharedBlksRead    int64   `db:"shared_blks_read"    json:"shared_blks_read"`
+	SharedBlksDirtied int64   `db:"shared_blks_dirtied" json:"shared_blks_dirtied"`
+	SharedBlksWritten int64   `db:"shared_blks_written" json:"shared_blks_written"`
+}
+
+// StatementsSnapshot describes a copy of the pg_stat_statements counters taken by
+// the service. Queries is the number of statements kept, Truncated reports that
+// the snapshot was capped to the statements with the largest total time.
+type StatementsSnapshot struct {
+	TakenAt   time.Time `json:"taken_at"`
+	Queries   int       `json:"queries"`
+	Truncated bool      `json:"truncated"`
+}
+
+// StatementsReport lists the top statements. With From set the figures are the
+// deltas between the snapshot taken at From and To, otherwise totals since the last reset.
+type StatementsReport struct {
+	Installed  bool        `json:"installed"`
+	Sort       string      `json:"sort"`
+	From       *time.Time  `json:"from,omitempty"`
+	To         time.Time   `json:"to"`
+	Statements []QueryStat `json:"statements"`
+}
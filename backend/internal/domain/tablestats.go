@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+// TableStats combines pg_stat_user_tables with the on-disk sizes of a table.
+// ToastBytes includes the TOAST index. BloatBytes is estimated from the average
+// row width in pg_stats and is nil for tables that were never analyzed.
+type TableStats struct {
+	Schema                    string     `db:"schema"                      json:"schema"`
+	Table                     string     `db:"table_name"                  json:"table"`
+	LiveTuples                int64      `db:"live_tuples"                 json:"live_tuples"`
+	DeadTuples                int64      `db:"dead_tuples"                 json:"dead_tuples"`
+	DeadRatio                 float64    `db:"dead_ratio"                  json:"dead_ratio"`
+	ModificationsSinceAnalyze int64      `db:"modifications_since_analyze" json:"modifications_since_analyze"`
+	SeqScans                  int64      `db:"seq_scans"                   json:"seq_scans"`
+	SeqTuplesRead             int64      `db:"seq_tuples_read"             json:"seq_tuples_read"`
+	IndexScans                int64      `db:"index_scans"                 json:"index_scans"`
+	IndexTuplesFetched        int64      `db:"index_tuples_fetched"        json:"index_tuples_fetched"`
+	LastVacuum                *time.Time `db:"last_vacuum"                 json:"last_vacuum"`
+	LastAutovacuum            *time.Time `db:"last_autovacuum"             json:"last_autovacuum"`
+	LastAnalyze               *time.Time `db:"last_analyze"                json:"last_analyze"`
+	LastAutoanalyze           *time.Time `db:"last_autoanalyze"            json:"last_autoanalyze"`
+	VacuumCount               int64      `db:"vacuum_count"                json:"vacuum_count"`
+	AutovacuumCount           int64      `db:"autovacuum_count"            json:"autovacuum_count"`
+	AnalyzeCount              int64      `db:"analyze_count"               json:"analyze_count"`
+	AutoanalyzeCount          int64      `db:"autoanalyze_count"           json:"autoanalyze_count"`
+	TableBytes                int64      `db:"table_bytes"                 json:"table_bytes"`
+	IndexesBytes              int64      `db:"indexes_bytes"               json:"indexes_bytes"`
+	ToastBytes                int64      `db:"toast_bytes"                 json:"toast_bytes"`
+	TotalBytes                int64      `db:"total_bytes"                 json:"total_bytes"`
+	BloatBytes                *int64     `db:"bloat_bytes"                 json:"bloat_bytes"`
+}
+
+// TableStatsReport lists the statistics of all tables, largest first, with totals.
+type TableStatsReport struct {
+	Tables       []TableStats `json:"tables"`
+	TotalBytes   int64        `json:"total_bytes"`
+	IndexesBytes int64        `json:"indexes_bytes"`
+	ToastBytes   int64        `json:"toast_bytes"`
+	BloatBytes   int64        `json:"bloat_bytes"`
+	LiveTuples   int64        `json:"live_tuples"`
+	DeadTuples   int64        `json:"dead_tuples"`
+}
+
+// VacuumOptions select the VACUUM variant. FULL rewrites the table under an
+// ACCESS EXCLUSIVE lock.
+type VacuumOptions struct {
+	Full    bool
+	Freeze  bool
+	Analyze bool
+}
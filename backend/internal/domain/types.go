@@ -0,0 +1,64 @@
+package domain
+
+// Types groups the user-defined types of a database.
+type Types struct {
+	Enums      []EnumType      `json:"enums"`
+	Composites []CompositeType `json:"composites"`
+	Domains    []DomainType    `json:"domains"`
+}
+
+type EnumType struct {
+	Schema string   `json:"schema"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+type CompositeType struct {
+	Schema     string          `db:"schema" json:"schema"`
+	Name       string          `db:"name"   json:"name"`
+	Attributes []TypeAttribute `db:"-"      json:"attributes"`
+}
+
+type TypeAttribute struct {
+	Name string `db:"name" json:"name"`
+	Type string `db:"type" json:"type"`
+}
+
+type DomainType struct {
+	Schema   string        `db:"schema"    json:"schema"`
+	Name     string        `db:"name"      json:"name"`
+	BaseType string        `db:"base_type" json:"base_type"`
+	Default  *string       `db:"default"   json:"default"`
+	NotNull  bool          `db:"not_null"  json:"not_null"`
+	Checks   []DomainCheck `db:"-"         json:"checks"`
+}
+
+// DomainCheck is a CHECK constraint of a domain. Expression is embedded as is
+// and refers to the checked value as VALUE.
+type DomainCheck struct {
+	Name       string `db:"name"       json:"name"`
+	Expression string `db:"expression" json:"expression"`
+}
+
+type EnumCreate struct {
+	Schema string   `json:"schema"`
+	Name   string   `json:"name"`
+	Labels []string `json:"labels"`
+}
+
+// EnumValueAdd adds a label to an enum, at the end unless Before or After is set.
+type EnumValueAdd struct {
+	Value       string `json:"value"`
+	Before      string `json:"before,omitempty"`
+	After       string `json:"after,omitempty"`
+	IfNotExists bool   `json:"if_not_exists"`
+}
+
+type DomainCreate struct {
+	Schema   string        `json:"schema"`
+	Name     string        `json:"name"`
+	BaseType string        `json:"base_type"`
+	Default  *string       `json:"default,omitempty"`
+	NotNull  bool          `json:"not_null"`
+	Checks   []DomainCheck `json:"checks"`
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// WipePreview lists everything DeleteAllTables would remove together with
+// the confirmation token required to perform it.
+type WipePreview struct {
+	Tables        []WipeTable  `json:"tables"`
+	Views         []WipeObject `json:"views"`
+	Sequences     []WipeObject `json:"sequences"`
+	EstimatedRows int64        `json:"estimated_rows"`
+	Token         string       `json:"token"`
+	ExpiresAt     time.Time    `json:"expires_at"`
+}
+
+type WipeTable struct {
+	Name          string `db:"name"           json:"name"`
+	EstimatedRows int64  `db:"estimated_rows" json:"estimated_rows"`
+}
+
+type WipeObject struct {
+	Schema string `db:"schema" json:"schema"`
+	Name   string `db:"name"   json:"name"`
+	Kind   string `db:"kind"   json:"kind"`
+}
+
+type TablesDeleted struct {
+	Backup  string     `json:"backup"`
+	Removed WipeReport `json:"removed"`
+	Message string     `json:"message"`
+	Success bool       `json:"success"`
+}
+
+// WipeOptions selects the objects of the public schema removed together with the tables.
+type WipeOptions struct {
+	Sequences         bool `json:"sequences"`
+	Views             bool `json:"views"`
+	MaterializedViews bool `json:"materialized_views"`
+	Types             bool `json:"types"`
+	Functions         bool `json:"functions"`
+}
+
+// WipeReport lists the removed objects. Cascaded holds the objects outside the
+// selected kinds that were dropped by CASCADE.
+type WipeReport struct {
+	Tables            []string     `json:"tables"`
+	Views             []string     `json:"views"`
+	MaterializedViews []string     `json:"materialized_views"`
+	Sequences         []string     `json:"sequences"`
+	Types             []string     `json:"types"`
+	Functions         []string     `json:"functions"`
+	Cascaded          []WipeObject `json:"cascaded"`
+}
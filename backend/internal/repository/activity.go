@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+const sessionColumns = `
+		SELECT a.pid,
+		       a.usename AS user_name,
+		       a.datname AS database,
+		       a.application_name,
+		       a.client_addr::text AS client,
+		       a.backend_type,
+		       a.state,
+		       a.wait_event_type,
+		       a.wait_event,
+		       a.query,
+		       a.backend_start,
+		       a.query_start,
+		       (extract(epoch FROM now() - a.query_start) * 1000)::bigint AS duration_ms,
+		       (extract(epoch FROM now() - a.xact_start) * 1000)::bigint AS xact_age_ms,
+		       pg_blocking_pids(a.pid) AS blocked_by`
+
+type sessionRow struct {
+	domain.Session
+	BlockedBy pq.Int64Array `db:"blocked_by"`
+}
+
+func (r sessionRow) session() domain.Session {
+	session := r.Session
+	session.BlockedBy = make([]int, 0, len(r.BlockedBy))
+	for _, pid := range r.BlockedBy {
+		session.BlockedBy = append(session.BlockedBy, int(pid))
+	}
+	return session
+}
+
+// Activity lists the sessions of the server except the one running the query.
+// Background processes are only included with all set.
+func (d *DB) Activity(ctx context.Context, all bool) ([]domain.Session, error) {
+	query := sessionColumns + `
+		FROM pg_stat_activity a
+		WHERE a.pid <> pg_backend_pid() AND ($1 OR a.backend_type = 'client backend')
+		ORDER BY a.query_start NULLS LAST, a.pid
+	`
+
+	var rows []sessionRow
+	if err := d.db.SelectContext(ctx, &rows, query, all); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	sessions := make([]domain.Session, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, row.session())
+	}
+	return sessions, nil
+}
+
+type lockSessionRow struct {
+	sessionRow
+	LockType *string `db:"lock_type"`
+	Mode     *string `db:"mode"`
+	Relation *string `db:"relation"`
+}
+
+// LockedSessions returns the sessions waiting for a lock held by another session
+// together with the sessions blocking them, and the lock each waiter waits for.
+func (d *DB) LockedSessions(ctx context.Context) ([]domain.Session, map[int]domain.LockWait, error) {
+	query := sessionColumns + `,
+		       w.locktype AS lock_type,
+		       w.mode,
+		       w.relation::regclass::text AS relation
+		FROM pg_stat_activity a
+		LEFT JOIN LATERAL (
+		    SELECT l.locktype, l.mode, l.relation FROM pg_locks l
+		    WHERE l.pid = a.pid AND NOT l.granted LIMIT 1
+		) w ON true
+		WHERE cardinality(pg_blocking_pids(a.pid)) > 0
+		   OR a.pid IN (SELECT unnest(pg_blocking_pids(b.pid)) FROM pg_stat_activity b)
+		ORDER BY a.query_start NULLS LAST, a.pid
+	`
+
+	var rows []lockSessionRow
+	if err := d.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	sessions := make([]domain.Session, 0, len(rows))
+	waits := make(map[int]domain.LockWait)
+	for _, row := range rows {
+		sessions = append(sessions, row.session())
+		if row.LockType != nil && row.Mode != nil {
+			waits[row.PID] = domain.LockWait{LockType: *row.LockType, Mode: *row.Mode, Relation: row.Relation}
+		}
+	}
+	return sessions, waits, nil
+}
+
+// CancelBackend cancels the current query of a backend. It reports false when
+// no backend with that pid exists.
+func (d *DB) CancelBackend(ctx context.Context, pid int) (bool, error) {
+	var ok bool
+	if err := d.db.GetContext(ctx, &ok, `SELECT pg_cancel_backend($1)`, pid); err != nil {
+		return false, fmt.Errorf("postgres: %w", err)
+	}
+	return ok, nil
+}
+
+// TerminateBackend terminates a backend. It reports false when no backend with
+// that pid exists.
+func (d *DB) TerminateBackend(ctx context.Context, pid int) (bool, error) {
+	var ok bool
+	if err := d.db.GetContext(ctx, &ok, `SELECT pg_terminate_backend($1)`, pid); err != nil {
+		return false, fmt.Errorf("postgres: %w", err)
+	}
+	return ok, nil
+}
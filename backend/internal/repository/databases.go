@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strings"
+)
+
+func (d *DB) Databases(ctx context.Context) ([]domain.Database, error) {
+	query := `
+		SELECT d.datname AS name,
+		       pg_get_userbyid(d.datdba) AS owner,
+		       pg_encoding_to_char(d.encoding) AS encoding,
+		       d.datcollate AS collation,
+		       d.datctype AS ctype,
+		       d.datconnlimit AS connection_limit,
+		       CASE WHEN has_database_privilege(d.datname, 'CONNECT')
+		            THEN pg_database_size(d.oid) END AS size_bytes,
+		       d.datistemplate AS is_template,
+		       d.datallowconn AS allow_connections,
+		       d.datname = current_database() AS current
+		FROM pg_database d
+		ORDER BY d.datname
+	`
+
+	var databases []domain.Database
+	err := d.db.SelectContext(ctx, &databases, query)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return databases, nil
+}
+
+func (d *DB) CreateDatabase(ctx context.Context, database domain.DatabaseCreate) error {
+	var sb strings.Builder
+	sb.WriteString("CREATE DATABASE " + pgclient.QuoteIdent(database.Name))
+
+	if database.Template != "" {
+		sb.WriteString(" TEMPLATE " + pgclient.QuoteIdent(database.Template))
+	}
+	if database.Owner != "" {
+		sb.WriteString(" OWNER " + pgclient.QuoteIdent(database.Owner))
+	}
+	if database.Encoding != "" {
+		sb.WriteString(" ENCODING " + pgclient.QuoteLiteral(database.Encoding))
+	}
+	if database.Collation != "" {
+		sb.WriteString(" LC_COLLATE " + pgclient.QuoteLiteral(database.Collation))
+	}
+	if database.Ctype != "" {
+		sb.WriteString(" LC_CTYPE " + pgclient.QuoteLiteral(database.Ctype))
+	}
+	if database.ConnectionLimit != nil {
+		fmt.Fprintf(&sb, " CONNECTION LIMIT %d", *database.ConnectionLimit)
+	}
+
+	_, err := d.db.ExecContext(ctx, sb.String())
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) RenameDatabase(ctx context.Context, name string, newName string) error {
+	query := fmt.Sprintf("ALTER DATABASE %s RENAME TO %s", pgclient.QuoteIdent(name), pgclient.QuoteIdent(newName))
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// DropDatabase drops the database. With force other sessions connected to it are terminated (PostgreSQL 13+).
+func (d *DB) DropDatabase(ctx context.Context, name string, force bool) error {
+	query := "DROP DATABASE " + pgclient.QuoteIdent(name)
+	if force {
+		query += " WITH (FORCE)"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	cfg "l6/internal/config"
 	"l6/internal/domain"
+	"l6/pkg/pgclient"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -26,12 +27,28 @@ func NewDB(db *sqlx.DB, cfg *cfg.PostgresConfig) *DB {
 	return &DB{db: db, cfg: cfg}
 }
 
+func (d *DB) Ping(ctx context.Context) error {
+	if err := d.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// serverVersion returns server_version_num of the server, e.g. 170002 for 17.2.
+func (d *DB) serverVersion(ctx context.Context) (int, error) {
+	var version int
+	if err := d.db.GetContext(ctx, &version, "SELECT current_setting('server_version_num')::int"); err != nil {
+		return 0, fmt.Errorf("postgres: server version: %w", err)
+	}
+	return version, nil
+}
+
 func (d *DB) Tables(ctx context.Context) ([]string, error) {
 
 	query := `
 		SELECT table_name 
 		FROM information_schema.tables 
-		WHERE table_schema='public'
+		WHERE table_schema='public' AND table_type = 'BASE TABLE'
 	`
 
 	var tables []string
@@ -115,7 +132,18 @@ func (d *DB) ExecuteQuery(ctx context.Context, query string) (string, error) {
 	return fmt.Sprintf("rows affected: %d", rows), nil
 }
 
-func (d *DB) CreateBackup(ctx context.Context, dir string) (domain.BackupCreated, error) {
+// backupFormats maps the backup formats to the pg_dump -F flag and the file
+// extension. Directory dumps get no extension.
+var backupFormats = map[string]struct{ flag, ext string }{
+	domain.BackupPlain:     {"p", ".sql"},
+	domain.BackupCustom:    {"c", ".dump"},
+	domain.BackupDirectory: {"d", ""},
+	domain.BackupTar:       {"t", ".tar"},
+}
+
+// CreateBackup dumps the database with pg_dump into a newly created file or
+// directory, which is removed when pg_dump fails or is cancelled.
+func (d *DB) CreateBackup(ctx context.Context, dir string, opts domain.BackupOptions, progress *domain.ToolProgress) (domain.BackupCreated, error) {
 	if dir == "" {
 		dir = os.Getenv("BACKUP_DIR")
 		if dir == "" {
@@ -127,36 +155,119 @@ func (d *DB) CreateBackup(ctx context.Context, dir string) (domain.BackupCreated
 		return domain.BackupCreated{}, fmt.Errorf("failed to create backup directory: %w", err)
 	}
 
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	filename := fmt.Sprintf("%s_backup_%s.sql", d.cfg.Database, timestamp)
+	if opts.Format == "" {
+		opts.Format = domain.BackupPlain
+	}
+	format, ok := backupFormats[opts.Format]
+	if !ok {
+		return domain.BackupCreated{}, fmt.Errorf("unknown backup format %q", opts.Format)
+	}
+
+	// Backups of concurrent jobs may start within the same second, so the name
+	// carries the nanoseconds and the file is created exclusively before pg_dump
+	// writes to it. pg_dump accepts an existing empty directory.
+	now := time.Now()
+	filename := fmt.Sprintf("%s_backup_%s_%09d%s", d.cfg.Database, now.Format("2006-01-02_15-04-05"), now.Nanosecond(), format.ext)
 	filePath := filepath.Join(dir, filename)
+	if opts.Format == domain.BackupDirectory {
+		if err := os.Mkdir(filePath, 0755); err != nil {
+			return domain.BackupCreated{}, fmt.Errorf("create backup directory: %w", err)
+		}
+	} else {
+		f, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return domain.BackupCreated{}, fmt.Errorf("create backup file: %w", err)
+		}
+		f.Close()
+	}
+	if progress != nil {
+		progress.SetPath(filePath)
+	}
 
-	cmd := exec.CommandContext(
-		ctx,
-		"pg_dump",
+	args := []string{
 		"-h", d.cfg.Host,
 		"-p", d.cfg.Port,
 		"-U", d.cfg.Username,
+		"-F", format.flag,
 		"-f", filePath,
-		d.cfg.Database,
-	)
+	}
+	if opts.Jobs > 1 {
+		args = append(args, "-j", strconv.Itoa(opts.Jobs))
+	}
+	args = append(args, backupArgs(opts)...)
+	args = append(args, d.cfg.Database)
+
+	cmd := exec.CommandContext(ctx, "pg_dump", args...)
 
 	if d.cfg.Password != "" {
 		cmd.Env = append(os.Environ(), fmt.Sprintf("PGPASSWORD=%s", d.cfg.Password))
 	}
 
-	if output, err := cmd.CombinedOutput(); err != nil {
-		return domain.BackupCreated{}, fmt.Errorf("pg_dump failed: %w, output: %s", err, string(output))
+	output := &toolOutput{progress: progress}
+	output.attach(cmd)
+
+	if err := cmd.Run(); err != nil {
+		_ = os.RemoveAll(filePath)
+		return domain.BackupCreated{}, fmt.Errorf("pg_dump failed: %w, output: %s", err, output.String())
+	}
+
+	meta, err := json.MarshalIndent(domain.BackupMeta{
+		Database:  d.cfg.Database,
+		CreatedAt: time.Now(),
+		Options:   opts,
+	}, "", "  ")
+	if err != nil {
+		return domain.BackupCreated{}, fmt.Errorf("marshal backup metadata: %w", err)
+	}
+	if err = os.WriteFile(filePath+domain.BackupMetaSuffix, meta, 0644); err != nil {
+		return domain.BackupCreated{}, fmt.Errorf("write backup metadata: %w", err)
 	}
 
 	return domain.BackupCreated{
 		Filename: filename,
+		Format:   opts.Format,
 		Message:  "Backup created successfully",
 		Success:  true,
 	}, nil
 }
 
-func (d *DB) RestoreBackup(ctx context.Context, filename string, dir string) error {
+// backupArgs translates the content options of a backup to pg_dump flags.
+func backupArgs(opts domain.BackupOptions) []string {
+	var args []string
+	if opts.SchemaOnly {
+		args = append(args, "--schema-only")
+	}
+	if opts.DataOnly {
+		args = append(args, "--data-only")
+	}
+	for _, table := range opts.Tables {
+		args = append(args, "--table="+table)
+	}
+	for _, table := range opts.ExcludeTables {
+		args = append(args, "--exclude-table="+table)
+	}
+	for _, schema := range opts.Schemas {
+		args = append(args, "--schema="+schema)
+	}
+	for _, schema := range opts.ExcludeSchemas {
+		args = append(args, "--exclude-schema="+schema)
+	}
+	if opts.NoOwner {
+		args = append(args, "--no-owner")
+	}
+	if opts.NoPrivileges {
+		args = append(args, "--no-privileges")
+	}
+	if opts.Clean {
+		args = append(args, "--clean", "--if-exists")
+	}
+	return args
+}
+
+// RestoreBackup restores a backup into the database, with psql for plain SQL
+// dumps and pg_restore for the archive formats. Restoring into another schema
+// pipes the SQL generated by pg_restore through psql, see restoreIntoSchema.
+func (d *DB) RestoreBackup(ctx context.Context, filename string, dir string, opts domain.RestoreOptions, progress *domain.ToolProgress) error {
 	if filename == "" {
 		return errors.New("filename is required")
 	}
@@ -168,47 +279,46 @@ func (d *DB) RestoreBackup(ctx context.Context, filename string, dir string) err
 		return fmt.Errorf("backup file does not exist: %w", err)
 	}
 
-	cmd := exec.CommandContext(
-		ctx,
-		"psql",
-		"-h", d.cfg.Host,
-		"-p", d.cfg.Port,
-		"-U", d.cfg.Username,
-		"-d", d.cfg.Database,
-		"-f", fullPath,
-	)
-
-	if d.cfg.Password != "" {
-		cmd.Env = append(os.Environ(), fmt.Sprintf("PGPASSWORD=%s", d.cfg.Password))
-	}
-
-	output, err := cmd.CombinedOutput()
+	format, err := pgclient.DetectDumpFormat(fullPath)
 	if err != nil {
-		return fmt.Errorf("pg_restore failed: %w, output: %s", err, string(output))
+		return fmt.Errorf("detect backup format: %w", err)
 	}
 
-	return nil
-}
+	database := d.cfg.Database
+	if opts.TargetDatabase != "" {
+		database = opts.TargetDatabase
+	}
 
-func (d *DB) DeleteAllTables(ctx context.Context) error {
-	query := `
-		DO $$ 
-		DECLARE 
-    		r RECORD;
-		BEGIN 
-    		EXECUTE 'SET session_replication_role = replica';
+	if format == domain.BackupPlain {
+		args := []string{dbnameArg(database)}
+		if opts.ExitOnError {
+			args = append(args, "-v", "ON_ERROR_STOP=1")
+		}
+		if opts.SingleTransaction {
+			args = append(args, "--single-transaction")
+		}
+		return d.runTool(d.pgCommand(ctx, "psql", append(args, "-f", fullPath)...), progress)
+	}
 
-    		FOR r IN (SELECT tablename FROM pg_tables WHERE schemaname = 'public') 
-    		LOOP
-        		EXECUTE 'DROP TABLE IF EXISTS public.' || r.tablename || ' CASCADE';
-    		END LOOP;
+	args, cleanup, err := d.restoreArgs(ctx, fullPath, opts)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
-    		EXECUTE 'SET session_replication_role = DEFAULT';
-		END $$;`
+	if opts.TargetSchema != "" {
+		return d.restoreIntoSchema(ctx, database, append(args, fullPath), opts, progress)
+	}
 
-	_, err := d.db.ExecContext(ctx, query)
-	if err != nil {
-		return fmt.Errorf("failed to delete all tables: %w", err)
+	args = append(args, dbnameArg(database))
+	if opts.SingleTransaction {
+		args = append(args, "--single-transaction")
 	}
-	return nil
+	if opts.ExitOnError {
+		args = append(args, "--exit-on-error")
+	}
+	if opts.Jobs > 1 {
+		args = append(args, "-j", strconv.Itoa(opts.Jobs))
+	}
+	return d.runTool(d.pgCommand(ctx, "pg_restore", append(args, fullPath)...), progress)
 }
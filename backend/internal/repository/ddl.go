@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExecDDL runs the statements in a single transaction.
+func (d *DB) ExecDDL(ctx context.Context, statements []string) error {
+	tx, err := d.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("postgres: begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	for _, statement := range statements {
+		if _, err = tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("postgres: %s: %w", statement, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("postgres: commit: %w", err)
+	}
+
+	return nil
+}
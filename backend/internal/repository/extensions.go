@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+
+	"github.com/lib/pq"
+)
+
+type extensionRow struct {
+	domain.Extension
+	Versions pq.StringArray `db:"versions"`
+}
+
+// Extensions lists the extensions available to the server with the versions they
+// can be installed or updated to, and their state in the current database. Dotted
+// numeric versions are ordered by their components, so 1.10 follows 1.9; other
+// versions follow them in text order.
+func (d *DB) Extensions(ctx context.Context) ([]domain.Extension, error) {
+	query := `
+		SELECT a.name, a.default_version, a.installed_version, n.nspname AS schema,
+		       coalesce(e.extrelocatable, false) AS relocatable,
+		       coalesce(a.installed_version <> a.default_version, false) AS update_available,
+		       a.comment,
+		       array(SELECT v.version FROM pg_available_extension_versions v
+		             WHERE v.name = a.name
+		             ORDER BY CASE WHEN v.version ~ '^[0-9]+(\.[0-9]+)*$'
+		                           THEN string_to_array(v.version, '.')::numeric[] END,
+		                      v.version) AS versions
+		FROM pg_available_extensions a
+		LEFT JOIN pg_extension e ON e.extname = a.name
+		LEFT JOIN pg_namespace n ON n.oid = e.extnamespace
+		ORDER BY a.name
+	`
+
+	var rows []extensionRow
+	if err := d.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	extensions := make([]domain.Extension, 0, len(rows))
+	for _, row := range rows {
+		extension := row.Extension
+		extension.Versions = []string(row.Versions)
+		if extension.Versions == nil {
+			extension.Versions = []string{}
+		}
+		extensions = append(extensions, extension)
+	}
+
+	return extensions, nil
+}
+
+func (d *DB) CreateExtension(ctx context.Context, create domain.ExtensionCreate) error {
+	query := "CREATE EXTENSION " + pgclient.QuoteIdent(create.Name)
+	if create.Schema != "" {
+		query += " SCHEMA " + pgclient.QuoteIdent(create.Schema)
+	}
+	if create.Version != "" {
+		query += " VERSION " + pgclient.QuoteLiteral(create.Version)
+	}
+	if create.Cascade {
+		query += " CASCADE"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) AlterExtension(ctx context.Context, name string, alter domain.ExtensionAlter) error {
+	var statements []string
+	if alter.Update || alter.Version != "" {
+		statement := "ALTER EXTENSION " + pgclient.QuoteIdent(name) + " UPDATE"
+		if alter.Version != "" {
+			statement += " TO " + pgclient.QuoteLiteral(alter.Version)
+		}
+		statements = append(statements, statement)
+	}
+	if alter.Schema != "" {
+		statements = append(statements,
+			"ALTER EXTENSION "+pgclient.QuoteIdent(name)+" SET SCHEMA "+pgclient.QuoteIdent(alter.Schema))
+	}
+
+	return d.ExecDDL(ctx, statements)
+}
+
+func (d *DB) DropExtension(ctx context.Context, name string, cascade bool) error {
+	query := "DROP EXTENSION " + pgclient.QuoteIdent(name)
+	if cascade {
+		query += " CASCADE"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"time"
+)
+
+// HealthStats samples the figures of the health report. Tables with a dead tuple
+// ratio of at least deadRatio and active queries running longer than longQuery
+// are listed.
+func (d *DB) HealthStats(ctx context.Context, deadRatio float64, longQuery time.Duration) (domain.HealthStats, error) {
+	statsQuery := `
+		SELECT clock_timestamp() AS sampled_at,
+		       d.datname AS database,
+		       d.blks_hit AS blocks_hit,
+		       d.blks_read AS blocks_read,
+		       d.xact_commit AS commits,
+		       d.xact_rollback AS rollbacks,
+		       (SELECT count(*) FROM pg_stat_activity WHERE backend_type = 'client backend') AS connections,
+		       current_setting('max_connections')::int AS max_connections,
+		       pg_is_in_recovery() AS in_recovery,
+		       (SELECT count(*) FROM pg_stat_replication) AS replicas,
+		       CASE WHEN pg_is_in_recovery()
+		            THEN extract(epoch FROM now() - pg_last_xact_replay_timestamp())
+		            ELSE (SELECT max(extract(epoch FROM replay_lag)) FROM pg_stat_replication)
+		       END::float8 AS replication_lag_seconds,
+		       (SELECT extract(epoch FROM max(now() - xact_start))::float8 FROM pg_stat_activity
+		        WHERE xact_start IS NOT NULL AND pid <> pg_backend_pid()) AS oldest_xact_seconds,
+		       f.datname AS frozen_xid_database,
+		       f.age AS frozen_xid_age,
+		       CASE WHEN pg_is_in_recovery() THEN NULL
+		            ELSE pg_wal_lsn_diff(pg_current_wal_lsn(), '0/0')::bigint
+		       END AS wal_bytes
+		FROM pg_stat_database d
+		CROSS JOIN LATERAL (
+		    SELECT datname, age(datfrozenxid)::bigint AS age FROM pg_database
+		    ORDER BY age(datfrozenxid) DESC LIMIT 1
+		) f
+		WHERE d.datname = current_database()
+	`
+
+	deadTuplesQuery := `
+		SELECT schemaname AS schema, relname AS table_name,
+		       n_live_tup AS live, n_dead_tup AS dead,
+		       n_dead_tup::float8 / (n_live_tup + n_dead_tup) AS ratio
+		FROM pg_stat_user_tables
+		WHERE n_live_tup + n_dead_tup >= 1000
+		  AND n_dead_tup::float8 / (n_live_tup + n_dead_tup) >= $1
+		ORDER BY ratio DESC
+		LIMIT 20
+	`
+
+	longQueriesQuery := sessionColumns + `
+		FROM pg_stat_activity a
+		WHERE a.state = 'active' AND a.pid <> pg_backend_pid()
+		  AND now() - a.query_start > $1 * interval '1 second'
+		ORDER BY a.query_start
+	`
+
+	var stats domain.HealthStats
+	if err := d.db.GetContext(ctx, &stats, statsQuery); err != nil {
+		return domain.HealthStats{}, fmt.Errorf("postgres: stats: %w", err)
+	}
+
+	stats.DeadTuples = []domain.TableDeadTuples{}
+	if err := d.db.SelectContext(ctx, &stats.DeadTuples, deadTuplesQuery, deadRatio); err != nil {
+		return domain.HealthStats{}, fmt.Errorf("postgres: dead tuples: %w", err)
+	}
+
+	var rows []sessionRow
+	if err := d.db.SelectContext(ctx, &rows, longQueriesQuery, longQuery.Seconds()); err != nil {
+		return domain.HealthStats{}, fmt.Errorf("postgres: long queries: %w", err)
+	}
+	stats.LongQueries = make([]domain.Session, 0, len(rows))
+	for _, row := range rows {
+		stats.LongQueries = append(stats.LongQueries, row.session())
+	}
+
+	return stats, nil
+}
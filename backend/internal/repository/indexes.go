@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type indexRow struct {
+	domain.Index
+	Same pq.StringArray `db:"same"`
+}
+
+// Indexes lists the indexes of the given schemas, optionally of a single table.
+// Indexes on the same table with the same columns, operator classes, expressions
+// and predicate are reported as duplicates of each other.
+func (d *DB) Indexes(ctx context.Context, schemas []string, table string) ([]domain.Index, error) {
+	query := `
+		SELECT n.nspname AS schema, t.relname AS table_name, c.relname AS name,
+		       pg_get_indexdef(i.indexrelid) AS definition, am.amname AS method,
+		       i.indisunique AS is_unique, i.indisprimary AS is_primary, i.indisvalid AS is_valid,
+		       EXISTS (SELECT 1 FROM pg_constraint con WHERE con.conindid = i.indexrelid) AS is_constraint,
+		       pg_relation_size(i.indexrelid) AS size_bytes,
+		       CASE WHEN am.amname = 'btree' AND c.reltuples >= 0 THEN
+		           greatest(0, c.relpages - ceil(c.reltuples * (coalesce(w.width, 8) + 12)
+		               / ((current_setting('block_size')::int - 24) * 0.9)))::bigint
+		           * current_setting('block_size')::bigint
+		       END AS bloat_bytes,
+		       coalesce(s.idx_scan, 0) AS scans,
+		       coalesce(s.idx_tup_read, 0) AS tuples_read,
+		       coalesce(s.idx_tup_fetch, 0) AS tuples_fetched,
+		       coalesce(s.idx_scan, 0) = 0 AND NOT i.indisunique AND NOT i.indisprimary AS unused,
+		       array_agg(c.relname) OVER (PARTITION BY i.indrelid, i.indkey::text, i.indclass::text,
+		           coalesce(pg_get_expr(i.indexprs, i.indrelid), ''),
+		           coalesce(pg_get_expr(i.indpred, i.indrelid), '')) AS same
+		FROM pg_index i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		JOIN pg_class t ON t.oid = i.indrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN pg_am am ON am.oid = c.relam
+		LEFT JOIN pg_stat_user_indexes s ON s.indexrelid = i.indexrelid
+		LEFT JOIN LATERAL (
+		    SELECT sum(coalesce(st.avg_width, 8)) AS width
+		    FROM unnest(i.indkey) AS k(attnum)
+		    LEFT JOIN pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum
+		    LEFT JOIN pg_stats st ON st.schemaname = n.nspname AND st.tablename = t.relname AND st.attname = a.attname
+		) w ON true
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + ` AND ($2 = '' OR t.relname = $2)
+		ORDER BY 1, 2, 3
+	`
+
+	var rows []indexRow
+	if err := d.db.SelectContext(ctx, &rows, query, textArray(schemas), table); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	indexes := make([]domain.Index, 0, len(rows))
+	for _, row := range rows {
+		index := row.Index
+		index.DuplicateOf = []string{}
+		for _, name := range row.Same {
+			if name != index.Name {
+				index.DuplicateOf = append(index.DuplicateOf, name)
+			}
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, nil
+}
+
+func (d *DB) CreateIndex(ctx context.Context, index domain.IndexCreate) error {
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if index.Unique {
+		sb.WriteString("UNIQUE ")
+	}
+	sb.WriteString("INDEX ")
+	if index.Concurrently {
+		sb.WriteString("CONCURRENTLY ")
+	}
+	if index.Name != "" {
+		sb.WriteString(pgclient.QuoteIdent(index.Name) + " ")
+	}
+	sb.WriteString("ON " + pgclient.QuoteQualified(index.Schema, index.Table))
+	sb.WriteString(" USING " + index.Method)
+
+	keys := make([]string, 0, len(index.Columns)+len(index.Expressions))
+	for _, column := range index.Columns {
+		keys = append(keys, pgclient.QuoteIdent(column))
+	}
+	for _, expression := range index.Expressions {
+		keys = append(keys, "("+expression+")")
+	}
+	sb.WriteString(" (" + strings.Join(keys, ", ") + ")")
+
+	if len(index.Include) > 0 {
+		include := make([]string, 0, len(index.Include))
+		for _, column := range index.Include {
+			include = append(include, pgclient.QuoteIdent(column))
+		}
+		sb.WriteString(" INCLUDE (" + strings.Join(include, ", ") + ")")
+	}
+	if index.Where != "" {
+		sb.WriteString(" WHERE " + index.Where)
+	}
+
+	// CONCURRENTLY cannot run inside a transaction block, the statement is sent on its own.
+	_, err := d.db.ExecContext(ctx, sb.String())
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DropIndex(ctx context.Context, schema, name string, concurrently, cascade bool) error {
+	query := "DROP INDEX "
+	if concurrently {
+		query += "CONCURRENTLY "
+	}
+	query += pgclient.QuoteQualified(schema, name)
+	if cascade {
+		query += " CASCADE"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) ReindexIndex(ctx context.Context, schema, name string, concurrently bool) error {
+	query := "REINDEX INDEX "
+	if concurrently {
+		query += "CONCURRENTLY "
+	}
+	query += pgclient.QuoteQualified(schema, name)
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type publicationTableRow struct {
+	Publication string `db:"publication"`
+	domain.PublicationTable
+}
+
+type subscriptionRow struct {
+	domain.Subscription
+	Publications pq.StringArray `db:"publications"`
+}
+
+func (d *DB) Publications(ctx context.Context) ([]domain.Publication, error) {
+	query := `
+		SELECT p.pubname AS name, pg_get_userbyid(p.pubowner) AS owner,
+		       p.puballtables AS all_tables, p.pubinsert AS "insert", p.pubupdate AS "update",
+		       p.pubdelete AS "delete", p.pubtruncate AS "truncate", p.pubviaroot AS via_root
+		FROM pg_publication p
+		ORDER BY p.pubname
+	`
+
+	tablesQuery := `
+		SELECT pubname AS publication, schemaname AS schema, tablename AS table_name
+		FROM pg_publication_tables
+		ORDER BY 1, 2, 3
+	`
+
+	publications := []domain.Publication{}
+	if err := d.db.SelectContext(ctx, &publications, query); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	var tables []publicationTableRow
+	if err := d.db.SelectContext(ctx, &tables, tablesQuery); err != nil {
+		return nil, fmt.Errorf("postgres: publication tables: %w", err)
+	}
+
+	byName := make(map[string][]domain.PublicationTable, len(publications))
+	for _, table := range tables {
+		byName[table.Publication] = append(byName[table.Publication], table.PublicationTable)
+	}
+	for i := range publications {
+		publications[i].Tables = byName[publications[i].Name]
+		if publications[i].Tables == nil {
+			publications[i].Tables = []domain.PublicationTable{}
+		}
+	}
+
+	return publications, nil
+}
+
+func (d *DB) CreatePublication(ctx context.Context, create domain.PublicationCreate) error {
+	query := "CREATE PUBLICATION " + pgclient.QuoteIdent(create.Name)
+	switch {
+	case create.AllTables:
+		query += " FOR ALL TABLES"
+	case len(create.Tables) > 0:
+		query += " FOR TABLE " + publicationTables(create.Tables)
+	}
+	if len(create.Operations) > 0 {
+		query += " WITH (publish = " + pgclient.QuoteLiteral(strings.Join(create.Operations, ", ")) + ")"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// AlterPublication applies the changes in a single transaction, renaming last.
+func (d *DB) AlterPublication(ctx context.Context, name string, alter domain.PublicationAlter) error {
+	prefix := "ALTER PUBLICATION " + pgclient.QuoteIdent(name)
+
+	var statements []string
+	if len(alter.AddTables) > 0 {
+		statements = append(statements, prefix+" ADD TABLE "+publicationTables(alter.AddTables))
+	}
+	if len(alter.DropTables) > 0 {
+		statements = append(statements, prefix+" DROP TABLE "+publicationTables(alter.DropTables))
+	}
+	if len(alter.Operations) > 0 {
+		statements = append(statements,
+			prefix+" SET (publish = "+pgclient.QuoteLiteral(strings.Join(alter.Operations, ", "))+")")
+	}
+	if alter.RenameTo != "" {
+		statements = append(statements, prefix+" RENAME TO "+pgclient.QuoteIdent(alter.RenameTo))
+	}
+
+	return d.ExecDDL(ctx, statements)
+}
+
+func (d *DB) DropPublication(ctx context.Context, name string) error {
+	_, err := d.db.ExecContext(ctx, "DROP PUBLICATION "+pgclient.QuoteIdent(name))
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// Subscriptions lists the subscriptions of the current database. The connection
+// strings are not read, they may contain passwords. Only the apply worker is
+// joined: from PostgreSQL 16 a subscription streaming in parallel also has
+// parallel apply workers without a relation, which have a leader_pid.
+func (d *DB) Subscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	version, err := d.serverVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	worker := "w.subid = s.oid AND w.relid IS NULL"
+	if version >= 160000 {
+		worker += " AND (w.leader_pid IS NULL OR w.leader_pid = w.pid)"
+	}
+
+	query := `
+		SELECT s.subname AS name, pg_get_userbyid(s.subowner) AS owner, s.subenabled AS enabled,
+		       s.subpublications AS publications, s.subslotname AS slot_name,
+		       w.pid AS worker_pid, w.received_lsn::text AS received_lsn,
+		       w.latest_end_lsn::text AS latest_end_lsn, w.last_msg_receipt_time, w.latest_end_time,
+		       (SELECT count(*) FROM pg_subscription_rel r WHERE r.srsubid = s.oid) AS tables,
+		       (SELECT count(*) FROM pg_subscription_rel r
+		        WHERE r.srsubid = s.oid AND r.srsubstate <> 'r') AS tables_syncing
+		FROM pg_subscription s
+		LEFT JOIN pg_stat_subscription w ON ` + worker + `
+		WHERE s.subdbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+		ORDER BY s.subname
+	`
+
+	var rows []subscriptionRow
+	if err := d.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	subscriptions := make([]domain.Subscription, 0, len(rows))
+	for _, row := range rows {
+		subscription := row.Subscription
+		subscription.Publications = []string(row.Publications)
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions, nil
+}
+
+func (d *DB) CreateSubscription(ctx context.Context, create domain.SubscriptionCreate) error {
+	query := "CREATE SUBSCRIPTION " + pgclient.QuoteIdent(create.Name) +
+		" CONNECTION " + pgclient.QuoteLiteral(create.ConnInfo) +
+		" PUBLICATION " + quoteIdents(create.Publications)
+
+	var options []string
+	if create.Enabled != nil {
+		options = append(options, "enabled = "+strconv.FormatBool(*create.Enabled))
+	}
+	if create.CopyData != nil {
+		options = append(options, "copy_data = "+strconv.FormatBool(*create.CopyData))
+	}
+	if len(options) > 0 {
+		query += " WITH (" + strings.Join(options, ", ") + ")"
+	}
+
+	// Creating the replication slot on the publisher cannot run inside a transaction block.
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// AlterSubscription changes the publications before enabling or disabling, each
+// statement on its own as SET PUBLICATION with refresh cannot run inside a transaction block.
+func (d *DB) AlterSubscription(ctx context.Context, name string, alter domain.SubscriptionAlter) error {
+	prefix := "ALTER SUBSCRIPTION " + pgclient.QuoteIdent(name)
+
+	var statements []string
+	if len(alter.Publications) > 0 {
+		statements = append(statements, prefix+" SET PUBLICATION "+quoteIdents(alter.Publications))
+	}
+	if alter.Enabled != nil {
+		if *alter.Enabled {
+			statements = append(statements, prefix+" ENABLE")
+		} else {
+			statements = append(statements, prefix+" DISABLE")
+		}
+	}
+
+	for _, statement := range statements {
+		if _, err := d.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("postgres: %s: %w", statement, err)
+		}
+	}
+	return nil
+}
+
+// RefreshSubscription fetches the current table list of the subscribed publications,
+// copying the data of added tables when copyData is set.
+func (d *DB) RefreshSubscription(ctx context.Context, name string, copyData bool) error {
+	query := "ALTER SUBSCRIPTION " + pgclient.QuoteIdent(name) +
+		" REFRESH PUBLICATION WITH (copy_data = " + strconv.FormatBool(copyData) + ")"
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DropSubscription(ctx context.Context, name string) error {
+	// Dropping the replication slot on the publisher cannot run inside a transaction block.
+	_, err := d.db.ExecContext(ctx, "DROP SUBSCRIPTION "+pgclient.QuoteIdent(name))
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func publicationTables(tables []domain.PublicationTable) string {
+	quoted := make([]string, 0, len(tables))
+	for _, table := range tables {
+		quoted = append(quoted, pgclient.QuoteQualified(table.Schema, table.Table))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func quoteIdents(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, pgclient.QuoteIdent(name))
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// migrationLockKey is the advisory lock key held while migrations run.
+const migrationLockKey int64 = 0x6c365f6d6967
+
+// AppliedMigrations returns the rows of the tracking table, or nothing if it does not exist yet.
+func (d *DB) AppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error) {
+	var exists bool
+	err := d.db.GetContext(ctx, &exists, `SELECT to_regclass('public.schema_migrations') IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	if !exists {
+		return []domain.AppliedMigration{}, nil
+	}
+
+	query := `
+		SELECT version, name, checksum, applied_at
+		FROM public.schema_migrations
+		ORDER BY version
+	`
+
+	var migrations []domain.AppliedMigration
+	err = d.db.SelectContext(ctx, &migrations, query)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return migrations, nil
+}
+
+// RunMigrations executes the steps in order, each in its own transaction, while
+// holding an advisory lock so that concurrent runs are rejected. Steps that are
+// already in the wanted state are skipped.
+func (d *DB) RunMigrations(ctx context.Context, steps []domain.MigrationStep) ([]domain.MigrationExecuted, error) {
+	conn, err := d.db.Connx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	defer conn.Close()
+
+	var locked bool
+	if err = conn.GetContext(ctx, &locked, `SELECT pg_try_advisory_lock($1)`, migrationLockKey); err != nil {
+		return nil, fmt.Errorf("postgres: advisory lock: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("%w: another migration run is in progress", domain.ErrConflict)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockKey) //nolint:errcheck // the lock is released with the session anyway
+
+	_, err = conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS public.schema_migrations (
+			version    bigint PRIMARY KEY,
+			name       text        NOT NULL,
+			checksum   text        NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: create migrations table: %w", err)
+	}
+
+	var versions []int64
+	if err = conn.SelectContext(ctx, &versions, `SELECT version FROM public.schema_migrations`); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	applied := make(map[int64]bool, len(versions))
+	for _, version := range versions {
+		applied[version] = true
+	}
+
+	executed := make([]domain.MigrationExecuted, 0, len(steps))
+	for _, step := range steps {
+		if applied[step.Version] != step.Down {
+			continue
+		}
+
+		start := time.Now()
+		if err = runMigrationStep(ctx, conn, step); err != nil {
+			return executed, err
+		}
+
+		direction := "up"
+		if step.Down {
+			direction = "down"
+		}
+		executed = append(executed, domain.MigrationExecuted{
+			Version:    step.Version,
+			Name:       step.Name,
+			Direction:  direction,
+			DurationMs: time.Since(start).Milliseconds(),
+		})
+	}
+
+	return executed, nil
+}
+
+func runMigrationStep(ctx context.Context, conn *sqlx.Conn, step domain.MigrationStep) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	if _, err = tx.ExecContext(ctx, step.SQL); err != nil {
+		return fmt.Errorf("migration %d_%s: %w", step.Version, step.Name, err)
+	}
+
+	if step.Down {
+		_, err = tx.ExecContext(ctx, `DELETE FROM public.schema_migrations WHERE version = $1`, step.Version)
+	} else {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO public.schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`,
+			step.Version, step.Name, step.Checksum)
+	}
+	if err != nil {
+		return fmt.Errorf("migration %d_%s: track: %w", step.Version, step.Name, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("migration %d_%s: commit: %w", step.Version, step.Name, err)
+	}
+
+	return nil
+}
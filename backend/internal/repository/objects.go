@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+)
+
+const viewColumns = `
+		SELECT n.nspname AS schema,
+		       c.relname AS name,
+		       c.relkind = 'm' AS materialized,
+		       pg_get_userbyid(c.relowner) AS owner,
+		       c.relispopulated AS populated,
+		       pg_total_relation_size(c.oid) AS size_bytes,
+		       obj_description(c.oid, 'pg_class') AS comment`
+
+const functionColumns = `
+		SELECT n.nspname AS schema,
+		       p.proname AS name,
+		       CASE p.prokind WHEN 'p' THEN 'procedure' WHEN 'a' THEN 'aggregate'
+		                      WHEN 'w' THEN 'window' ELSE 'function' END AS kind,
+		       pg_get_function_identity_arguments(p.oid) AS arguments,
+		       pg_get_function_result(p.oid) AS result,
+		       l.lanname AS language,
+		       CASE p.provolatile WHEN 'i' THEN 'immutable' WHEN 's' THEN 'stable' ELSE 'volatile' END AS volatility,
+		       p.proisstrict AS strict,
+		       p.prosecdef AS security_definer,
+		       pg_get_userbyid(p.proowner) AS owner,
+		       obj_description(p.oid, 'pg_proc') AS comment`
+
+// Views lists views and materialized views without their definitions.
+func (d *DB) Views(ctx context.Context, schemas []string) ([]domain.View, error) {
+	query := viewColumns + `
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('v', 'm') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "c.oid") + `
+		ORDER BY 1, 2
+	`
+
+	views := []domain.View{}
+	if err := d.db.SelectContext(ctx, &views, query, textArray(schemas)); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return views, nil
+}
+
+func (d *DB) View(ctx context.Context, schema, name string) (domain.View, error) {
+	query := viewColumns + `,
+		       pg_get_viewdef(c.oid, true) AS definition
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('v', 'm') AND n.nspname = $1 AND c.relname = $2
+	`
+
+	var view domain.View
+	err := d.db.GetContext(ctx, &view, query, schema, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.View{}, fmt.Errorf("%w: view %s.%s", domain.ErrNotFound, schema, name)
+	}
+	if err != nil {
+		return domain.View{}, fmt.Errorf("postgres: %w", err)
+	}
+	return view, nil
+}
+
+func (d *DB) RefreshMaterializedView(ctx context.Context, schema, name string, concurrently bool) error {
+	query := "REFRESH MATERIALIZED VIEW "
+	if concurrently {
+		query += "CONCURRENTLY "
+	}
+	query += pgclient.QuoteQualified(schema, name)
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// Functions lists functions and procedures with their signatures, without sources.
+func (d *DB) Functions(ctx context.Context, schemas []string) ([]domain.Function, error) {
+	query := functionColumns + `
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_language l ON l.oid = p.prolang
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "p.oid") + `
+		ORDER BY 1, 2, 4
+	`
+
+	functions := []domain.Function{}
+	if err := d.db.SelectContext(ctx, &functions, query, textArray(schemas)); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return functions, nil
+}
+
+// Function returns every overload of a routine with its source and full definition.
+func (d *DB) Function(ctx context.Context, schema, name string) ([]domain.Function, error) {
+	query := functionColumns + `,
+		       p.prosrc AS source,
+		       CASE WHEN p.prokind IN ('f', 'p', 'w') THEN pg_get_functiondef(p.oid) ELSE '' END AS definition
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		JOIN pg_language l ON l.oid = p.prolang
+		WHERE n.nspname = $1 AND p.proname = $2
+		ORDER BY 4
+	`
+
+	var functions []domain.Function
+	if err := d.db.SelectContext(ctx, &functions, query, schema, name); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	if len(functions) == 0 {
+		return nil, fmt.Errorf("%w: function %s.%s", domain.ErrNotFound, schema, name)
+	}
+	return functions, nil
+}
+
+// Triggers lists user triggers, optionally of a single table.
+func (d *DB) Triggers(ctx context.Context, schemas []string, table string) ([]domain.Trigger, error) {
+	query := `
+		SELECT n.nspname AS schema,
+		       c.relname AS table_name,
+		       t.tgname AS name,
+		       CASE WHEN t.tgtype & 2 <> 0 THEN 'BEFORE'
+		            WHEN t.tgtype & 64 <> 0 THEN 'INSTEAD OF' ELSE 'AFTER' END AS timing,
+		       concat_ws(' OR ',
+		           CASE WHEN t.tgtype & 4 <> 0 THEN 'INSERT' END,
+		           CASE WHEN t.tgtype & 16 <> 0 THEN 'UPDATE' END,
+		           CASE WHEN t.tgtype & 8 <> 0 THEN 'DELETE' END,
+		           CASE WHEN t.tgtype & 32 <> 0 THEN 'TRUNCATE' END) AS events,
+		       CASE WHEN t.tgtype & 1 <> 0 THEN 'row' ELSE 'statement' END AS level,
+		       p.oid::regproc::text AS function,
+		       CASE t.tgenabled WHEN 'O' THEN 'origin' WHEN 'D' THEN 'disabled'
+		                        WHEN 'R' THEN 'replica' WHEN 'A' THEN 'always' END AS enabled,
+		       pg_get_triggerdef(t.oid, true) AS definition
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		JOIN pg_proc p ON p.oid = t.tgfoid
+		WHERE NOT t.tgisinternal AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ($2 = '' OR c.relname = $2)
+		ORDER BY 1, 2, 3
+	`
+
+	triggers := []domain.Trigger{}
+	if err := d.db.SelectContext(ctx, &triggers, query, textArray(schemas), table); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return triggers, nil
+}
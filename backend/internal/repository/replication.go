@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"l6/internal/domain"
+)
+
+// ReplicationStatus reads the recovery state, the WAL senders, the replication
+// slots and, on a standby, the WAL receiver. WAL distances are measured from the
+// current WAL position on a primary and the last replayed position on a standby.
+func (d *DB) ReplicationStatus(ctx context.Context) (domain.ReplicationStatus, error) {
+	stateQuery := `
+		SELECT pg_is_in_recovery() AS in_recovery,
+		       (CASE WHEN pg_is_in_recovery() THEN coalesce(pg_last_wal_replay_lsn(), '0/0')
+		             ELSE pg_current_wal_lsn()
+		        END)::text AS current_lsn
+	`
+
+	replicasQuery := `
+		SELECT pid, usename AS user_name, application_name, host(client_addr) AS client_addr,
+		       state, sync_state,
+		       sent_lsn::text AS sent_lsn, write_lsn::text AS write_lsn,
+		       flush_lsn::text AS flush_lsn, replay_lsn::text AS replay_lsn,
+		       extract(epoch FROM write_lag)::float8 AS write_lag_seconds,
+		       extract(epoch FROM flush_lag)::float8 AS flush_lag_seconds,
+		       extract(epoch FROM replay_lag)::float8 AS replay_lag_seconds,
+		       pg_wal_lsn_diff($1::pg_lsn, replay_lsn)::bigint AS replay_lag_bytes,
+		       backend_start
+		FROM pg_stat_replication
+		ORDER BY application_name, pid
+	`
+
+	slotsQuery := `
+		SELECT slot_name AS name, slot_type, plugin, database, temporary, active, active_pid,
+		       restart_lsn::text AS restart_lsn, confirmed_flush_lsn::text AS confirmed_flush_lsn,
+		       wal_status,
+		       pg_wal_lsn_diff($1::pg_lsn, restart_lsn)::bigint AS retained_wal_bytes
+		FROM pg_replication_slots
+		ORDER BY slot_name
+	`
+
+	receiverQuery := `
+		SELECT pid, status, sender_host, sender_port, slot_name,
+		       flushed_lsn::text AS flushed_lsn, latest_end_lsn::text AS latest_end_lsn,
+		       last_msg_receipt_time,
+		       extract(epoch FROM now() - pg_last_xact_replay_timestamp())::float8 AS replay_delay_seconds
+		FROM pg_stat_wal_receiver
+	`
+
+	var status domain.ReplicationStatus
+	if err := d.db.QueryRowxContext(ctx, stateQuery).Scan(&status.InRecovery, &status.CurrentLSN); err != nil {
+		return domain.ReplicationStatus{}, fmt.Errorf("postgres: recovery state: %w", err)
+	}
+
+	status.Replicas = []domain.Replica{}
+	if err := d.db.SelectContext(ctx, &status.Replicas, replicasQuery, status.CurrentLSN); err != nil {
+		return domain.ReplicationStatus{}, fmt.Errorf("postgres: replicas: %w", err)
+	}
+
+	status.Slots = []domain.ReplicationSlot{}
+	if err := d.db.SelectContext(ctx, &status.Slots, slotsQuery, status.CurrentLSN); err != nil {
+		return domain.ReplicationStatus{}, fmt.Errorf("postgres: replication slots: %w", err)
+	}
+
+	var receiver domain.WALReceiver
+	err := d.db.GetContext(ctx, &receiver, receiverQuery)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return domain.ReplicationStatus{}, fmt.Errorf("postgres: wal receiver: %w", err)
+	default:
+		status.WALReceiver = &receiver
+	}
+
+	return status, nil
+}
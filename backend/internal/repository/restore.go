@@ -0,0 +1,334 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// archiveTypes are the entry types of pg_restore -l made of several words,
+// longest first so that prefixes do not shadow longer types.
+var archiveTypes = []string{
+	"PUBLICATION TABLES IN SCHEMA",
+	"TEXT SEARCH CONFIGURATION",
+	"TEXT SEARCH DICTIONARY",
+	"MATERIALIZED VIEW DATA",
+	"FOREIGN DATA WRAPPER",
+	"TEXT SEARCH TEMPLATE",
+	"TEXT SEARCH PARSER",
+	"DATABASE PROPERTIES",
+	"SEQUENCE OWNED BY",
+	"PUBLICATION TABLE",
+	"MATERIALIZED VIEW",
+	"CHECK CONSTRAINT",
+	"OPERATOR FAMILY",
+	"STATISTICS DATA",
+	"OPERATOR CLASS",
+	"ACCESS METHOD",
+	"EVENT TRIGGER",
+	"FOREIGN TABLE",
+	"LARGE OBJECT",
+	"SEQUENCE SET",
+	"TABLE ATTACH",
+	"INDEX ATTACH",
+	"USER MAPPING",
+	"ROW SECURITY",
+	"FK CONSTRAINT",
+	"DEFAULT ACL",
+	"SHELL TYPE",
+	"TABLE DATA",
+	"BLOB DATA",
+}
+
+// archiveLine is a parsed line of pg_restore -l, kept verbatim for list files.
+type archiveLine struct {
+	entry domain.ArchiveEntry
+	line  string
+}
+
+// BackupContents lists the table of contents of an archive backup with pg_restore -l.
+func (d *DB) BackupContents(ctx context.Context, filename string, dir string) ([]domain.ArchiveEntry, error) {
+	lines, err := d.archiveList(ctx, filepath.Join(dir, filepath.Base(filename)))
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.ArchiveEntry, 0, len(lines))
+	for _, line := range lines {
+		entries = append(entries, line.entry)
+	}
+	return entries, nil
+}
+
+func (d *DB) archiveList(ctx context.Context, path string) ([]archiveLine, error) {
+	cmd := exec.CommandContext(ctx, "pg_restore", "-l", path)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("pg_restore -l failed: %w, output: %s", err, stderr.String())
+	}
+
+	var lines []archiveLine
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if line, ok := parseArchiveLine(scanner.Text()); ok {
+			lines = append(lines, line)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read pg_restore -l output: %w", err)
+	}
+
+	return lines, nil
+}
+
+// parseArchiveLine parses "id; tableoid oid TYPE schema name owner". Schema is "-"
+// for objects outside a schema, the owner may be empty and names may contain spaces.
+func parseArchiveLine(text string) (archiveLine, bool) {
+	if text == "" || strings.HasPrefix(text, ";") {
+		return archiveLine{}, false
+	}
+	id, rest, ok := strings.Cut(text, "; ")
+	if !ok {
+		return archiveLine{}, false
+	}
+	dumpID, err := strconv.Atoi(id)
+	if err != nil {
+		return archiveLine{}, false
+	}
+	fields := strings.SplitN(rest, " ", 3)
+	if len(fields) < 3 {
+		return archiveLine{}, false
+	}
+	rest = fields[2]
+
+	entry := domain.ArchiveEntry{ID: dumpID}
+	for _, typ := range archiveTypes {
+		if strings.HasPrefix(rest, typ+" ") {
+			entry.Type = typ
+			break
+		}
+	}
+	if entry.Type == "" {
+		entry.Type, _, _ = strings.Cut(rest, " ")
+	}
+	rest = strings.TrimPrefix(rest[len(entry.Type):], " ")
+
+	schema, rest, _ := strings.Cut(rest, " ")
+	if schema != "-" {
+		entry.Schema = schema
+	}
+	if i := strings.LastIndex(rest, " "); i >= 0 {
+		entry.Name, entry.Owner = rest[:i], rest[i+1:]
+	} else {
+		entry.Name = rest
+	}
+
+	return archiveLine{entry: entry, line: text}, true
+}
+
+// restoreArgs builds the pg_restore selection flags. Types and a target schema are
+// applied through a list file, which cleanup removes.
+func (d *DB) restoreArgs(ctx context.Context, path string, opts domain.RestoreOptions) ([]string, func(), error) {
+	cleanup := func() {}
+
+	var args []string
+	for _, schema := range opts.Schemas {
+		args = append(args, "--schema="+schema)
+	}
+	for _, table := range opts.Tables {
+		args = append(args, "--table="+table)
+	}
+	if opts.Clean {
+		args = append(args, "--clean", "--if-exists")
+	}
+
+	if len(opts.Types) == 0 && opts.TargetSchema == "" {
+		return args, cleanup, nil
+	}
+
+	lines, err := d.archiveList(ctx, path)
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	var list strings.Builder
+	for _, line := range lines {
+		if len(opts.Types) > 0 && !slices.Contains(opts.Types, line.entry.Type) {
+			continue
+		}
+		// The target schema is created by restoreIntoSchema instead.
+		if opts.TargetSchema != "" && line.entry.Type == "SCHEMA" {
+			continue
+		}
+		list.WriteString(line.line + "\n")
+	}
+
+	f, err := os.CreateTemp("", "restore-*.list")
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("create restore list: %w", err)
+	}
+	cleanup = func() { os.Remove(f.Name()) }
+	_, err = f.WriteString(list.String())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return nil, func() {}, fmt.Errorf("write restore list: %w", err)
+	}
+
+	return append(args, "--use-list="+f.Name()), cleanup, nil
+}
+
+// restoreIntoSchema restores the objects of the single selected schema under
+// opts.TargetSchema. pg_restore has no schema remapping, so in one transaction the
+// source schema is moved aside, the objects are restored into a fresh schema of
+// the same name, which is renamed to the target, and the source schema is put back.
+// References to the source schema inside function bodies are not rewritten.
+func (d *DB) restoreIntoSchema(ctx context.Context, database string, args []string, opts domain.RestoreOptions, progress *domain.ToolProgress) error {
+	source := opts.Schemas[0]
+	aside := "restore_aside_" + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	prelude := fmt.Sprintf(`DO $$ BEGIN
+		IF EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = %[1]s) THEN
+			EXECUTE 'ALTER SCHEMA ' || quote_ident(%[1]s) || ' RENAME TO ' || quote_ident(%[2]s);
+		END IF;
+	END $$; CREATE SCHEMA %[3]s;`,
+		pgclient.QuoteLiteral(source), pgclient.QuoteLiteral(aside), pgclient.QuoteIdent(source))
+	epilogue := fmt.Sprintf(`ALTER SCHEMA %[1]s RENAME TO %[2]s; DO $$ BEGIN
+		IF EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = %[3]s) THEN
+			EXECUTE 'ALTER SCHEMA ' || quote_ident(%[3]s) || ' RENAME TO ' || quote_ident(%[4]s);
+		END IF;
+	END $$;`,
+		pgclient.QuoteIdent(source), pgclient.QuoteIdent(opts.TargetSchema),
+		pgclient.QuoteLiteral(aside), pgclient.QuoteLiteral(source))
+
+	// Without a database pg_restore writes the SQL script to stdout.
+	dump := exec.CommandContext(ctx, "pg_restore", args...)
+	dumpOutput := &toolOutput{progress: progress}
+	dump.Stderr = dumpOutput.stderr()
+
+	restore := d.pgCommand(ctx, "psql", dbnameArg(database), "-v", "ON_ERROR_STOP=1", "--single-transaction",
+		"-c", prelude, "-f", "-", "-c", epilogue)
+	restoreOutput := &toolOutput{progress: progress}
+	restoreOutput.attach(restore)
+
+	// The parent closes its ends of the pipe once both tools are started, so that
+	// pg_restore fails on a closed pipe instead of blocking when psql exits early.
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return fmt.Errorf("pipe pg_restore output: %w", err)
+	}
+	dump.Stdout = writer
+	restore.Stdin = reader
+
+	if err = dump.Start(); err != nil {
+		reader.Close()
+		writer.Close()
+		return fmt.Errorf("start pg_restore: %w", err)
+	}
+	writer.Close()
+	err = restore.Start()
+	reader.Close()
+	if err != nil {
+		_ = dump.Wait()
+		return fmt.Errorf("start psql: %w", err)
+	}
+	restoreErr := restore.Wait()
+	dumpErr := dump.Wait()
+
+	if restoreErr != nil {
+		return fmt.Errorf("psql failed: %w, output: %s", restoreErr, restoreOutput.String())
+	}
+	if dumpErr != nil {
+		return fmt.Errorf("pg_restore failed: %w, output: %s", dumpErr, dumpOutput.String())
+	}
+	return nil
+}
+
+// dbnameArg passes database to a client tool as the dbname keyword of a connection
+// string. A bare -d value containing "=" or starting with postgres:// would be
+// taken as a whole connection string and could point the tool at another server.
+func dbnameArg(database string) string {
+	quoted := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(database)
+	return "--dbname=dbname='" + quoted + "'"
+}
+
+// pgCommand prepares a PostgreSQL client tool connecting to the server of the
+// repository as its user.
+func (d *DB) pgCommand(ctx context.Context, tool string, args ...string) *exec.Cmd {
+	args = append([]string{"-h", d.cfg.Host, "-p", d.cfg.Port, "-U", d.cfg.Username}, args...)
+	cmd := exec.CommandContext(ctx, tool, args...)
+
+	if d.cfg.Password != "" {
+		cmd.Env = append(os.Environ(), fmt.Sprintf("PGPASSWORD=%s", d.cfg.Password))
+	}
+
+	return cmd
+}
+
+func (d *DB) runTool(cmd *exec.Cmd, progress *domain.ToolProgress) error {
+	output := &toolOutput{progress: progress}
+	output.attach(cmd)
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w, output: %s", filepath.Base(cmd.Path), err, output.String())
+	}
+	return nil
+}
+
+// toolOutput collects the combined output of a client tool. os/exec copies stdout
+// and stderr from separate goroutines, so writes are serialized. Only stderr is
+// copied to the progress of the job, when it is set.
+type toolOutput struct {
+	mu       sync.Mutex
+	buf      bytes.Buffer
+	progress *domain.ToolProgress
+}
+
+type toolStream struct {
+	output *toolOutput
+	stderr bool
+}
+
+func (s toolStream) Write(b []byte) (int, error) {
+	s.output.mu.Lock()
+	defer s.output.mu.Unlock()
+
+	if s.stderr && s.output.progress != nil {
+		_, _ = s.output.progress.Write(b)
+	}
+	return s.output.buf.Write(b)
+}
+
+func (o *toolOutput) stdout() io.Writer { return toolStream{output: o} }
+
+func (o *toolOutput) stderr() io.Writer { return toolStream{output: o, stderr: true} }
+
+// attach sends both output streams of cmd to o.
+func (o *toolOutput) attach(cmd *exec.Cmd) {
+	cmd.Stdout = o.stdout()
+	cmd.Stderr = o.stderr()
+}
+
+func (o *toolOutput) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.buf.String()
+}
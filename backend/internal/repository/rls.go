@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+
+	"github.com/lib/pq"
+)
+
+type policyRow struct {
+	domain.Policy
+	Roles pq.StringArray `db:"roles"`
+}
+
+// TablesRLS lists the row-level security state of the tables of the given schemas.
+func (d *DB) TablesRLS(ctx context.Context, schemas []string) ([]domain.TableRLS, error) {
+	query := `
+		SELECT n.nspname AS schema,
+		       c.relname AS table_name,
+		       c.relrowsecurity AS enabled,
+		       c.relforcerowsecurity AS forced,
+		       (SELECT count(*) FROM pg_policy p WHERE p.polrelid = c.oid) AS policies
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		ORDER BY 1, 2
+	`
+
+	tables := []domain.TableRLS{}
+	if err := d.db.SelectContext(ctx, &tables, query, textArray(schemas)); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return tables, nil
+}
+
+func (d *DB) Policies(ctx context.Context, schemas []string, table string) ([]domain.Policy, error) {
+	query := `
+		SELECT p.schemaname AS schema,
+		       p.tablename AS table_name,
+		       p.policyname AS name,
+		       p.permissive,
+		       p.roles::text[] AS roles,
+		       p.cmd AS command,
+		       p.qual AS "using",
+		       p.with_check
+		FROM pg_policies p
+		JOIN pg_namespace n ON n.nspname = p.schemaname
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + ` AND ($2 = '' OR p.tablename = $2)
+		ORDER BY 1, 2, 3
+	`
+
+	var rows []policyRow
+	if err := d.db.SelectContext(ctx, &rows, query, textArray(schemas), table); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	policies := make([]domain.Policy, 0, len(rows))
+	for _, row := range rows {
+		policy := row.Policy
+		policy.Roles = row.Roles
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+func (d *DB) CreatePolicy(ctx context.Context, schema, table string, policy domain.PolicyCreate) error {
+	query := "CREATE POLICY " + pgclient.QuoteIdent(policy.Name) + " ON " + pgclient.QuoteQualified(schema, table)
+	if policy.Restrictive {
+		query += " AS RESTRICTIVE"
+	}
+	query += " FOR " + policy.Command
+	if len(policy.Roles) > 0 {
+		query += " TO " + quoteRoles(policy.Roles)
+	}
+	if policy.Using != "" {
+		query += " USING (" + policy.Using + ")"
+	}
+	if policy.WithCheck != "" {
+		query += " WITH CHECK (" + policy.WithCheck + ")"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// AlterPolicy applies the changes and the rename in one transaction.
+func (d *DB) AlterPolicy(ctx context.Context, schema, table, name string, alter domain.PolicyAlter) error {
+	prefix := "ALTER POLICY " + pgclient.QuoteIdent(name) + " ON " + pgclient.QuoteQualified(schema, table)
+
+	var statements []string
+	if len(alter.Roles) > 0 || alter.Using != nil || alter.WithCheck != nil {
+		statement := prefix
+		if len(alter.Roles) > 0 {
+			statement += " TO " + quoteRoles(alter.Roles)
+		}
+		if alter.Using != nil {
+			statement += " USING (" + *alter.Using + ")"
+		}
+		if alter.WithCheck != nil {
+			statement += " WITH CHECK (" + *alter.WithCheck + ")"
+		}
+		statements = append(statements, statement)
+	}
+	if alter.RenameTo != "" {
+		statements = append(statements, prefix+" RENAME TO "+pgclient.QuoteIdent(alter.RenameTo))
+	}
+
+	return d.ExecDDL(ctx, statements)
+}
+
+func (d *DB) DropPolicy(ctx context.Context, schema, table, name string) error {
+	query := "DROP POLICY " + pgclient.QuoteIdent(name) + " ON " + pgclient.QuoteQualified(schema, table)
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) SetTableRLS(ctx context.Context, schema, table string, change domain.RLSChange) error {
+	prefix := "ALTER TABLE " + pgclient.QuoteQualified(schema, table)
+
+	var statements []string
+	if change.Enabled != nil {
+		if *change.Enabled {
+			statements = append(statements, prefix+" ENABLE ROW LEVEL SECURITY")
+		} else {
+			statements = append(statements, prefix+" DISABLE ROW LEVEL SECURITY")
+		}
+	}
+	if change.Forced != nil {
+		if *change.Forced {
+			statements = append(statements, prefix+" FORCE ROW LEVEL SECURITY")
+		} else {
+			statements = append(statements, prefix+" NO FORCE ROW LEVEL SECURITY")
+		}
+	}
+
+	return d.ExecDDL(ctx, statements)
+}
@@ -0,0 +1,265 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type roleRow struct {
+	domain.Role
+	MemberOf pq.StringArray `db:"member_of"`
+	Members  pq.StringArray `db:"members"`
+}
+
+type privilegeRow struct {
+	ObjectType string         `db:"object_type"`
+	Schema     string         `db:"schema"`
+	Name       string         `db:"name"`
+	Owner      string         `db:"owner"`
+	Grantee    string         `db:"grantee"`
+	Privileges pq.StringArray `db:"privileges"`
+	Grantable  pq.StringArray `db:"grantable"`
+}
+
+// Roles lists the roles of the cluster except the predefined pg_* ones.
+func (d *DB) Roles(ctx context.Context) ([]domain.Role, error) {
+	query := `
+		SELECT r.rolname AS name,
+		       r.rolsuper AS superuser,
+		       r.rolinherit AS inherit,
+		       r.rolcreaterole AS create_role,
+		       r.rolcreatedb AS create_db,
+		       r.rolcanlogin AS login,
+		       r.rolreplication AS replication,
+		       r.rolbypassrls AS bypass_rls,
+		       r.rolconnlimit AS connection_limit,
+		       r.rolvaliduntil AS valid_until,
+		       ARRAY(SELECT g.rolname FROM pg_auth_members m JOIN pg_roles g ON g.oid = m.roleid
+		             WHERE m.member = r.oid ORDER BY 1) AS member_of,
+		       ARRAY(SELECT g.rolname FROM pg_auth_members m JOIN pg_roles g ON g.oid = m.member
+		             WHERE m.roleid = r.oid ORDER BY 1) AS members
+		FROM pg_roles r
+		WHERE r.rolname !~ '^pg_'
+		ORDER BY 1
+	`
+
+	var rows []roleRow
+	if err := d.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	roles := make([]domain.Role, 0, len(rows))
+	for _, row := range rows {
+		role := row.Role
+		role.MemberOf = row.MemberOf
+		role.Members = row.Members
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+func (d *DB) CreateRole(ctx context.Context, role domain.RoleCreate) error {
+	query := "CREATE ROLE " + pgclient.QuoteIdent(role.Name) + roleOptions(role.RoleAttributes)
+	if role.Password != "" {
+		query += " PASSWORD " + pgclient.QuoteLiteral(role.Password)
+	}
+	if len(role.InRoles) > 0 {
+		query += " IN ROLE " + quoteRoles(role.InRoles)
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// AlterRole applies attribute and membership changes and the rename in one transaction.
+func (d *DB) AlterRole(ctx context.Context, name string, alter domain.RoleAlter) error {
+	role := pgclient.QuoteIdent(name)
+
+	var statements []string
+	if options := roleOptions(alter.RoleAttributes); options != "" {
+		statements = append(statements, "ALTER ROLE "+role+options)
+	}
+	if len(alter.GrantRoles) > 0 {
+		statements = append(statements, "GRANT "+quoteRoles(alter.GrantRoles)+" TO "+role)
+	}
+	if len(alter.RevokeRoles) > 0 {
+		statements = append(statements, "REVOKE "+quoteRoles(alter.RevokeRoles)+" FROM "+role)
+	}
+	if alter.RenameTo != "" {
+		statements = append(statements, "ALTER ROLE "+role+" RENAME TO "+pgclient.QuoteIdent(alter.RenameTo))
+	}
+
+	return d.ExecDDL(ctx, statements)
+}
+
+func (d *DB) SetRolePassword(ctx context.Context, name, password string) error {
+	query := "ALTER ROLE " + pgclient.QuoteIdent(name) + " PASSWORD " + pgclient.QuoteLiteral(password)
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) DropRole(ctx context.Context, name string) error {
+	_, err := d.db.ExecContext(ctx, "DROP ROLE "+pgclient.QuoteIdent(name))
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) GrantPrivileges(ctx context.Context, change domain.PrivilegeChange) error {
+	query := "GRANT " + strings.Join(change.Privileges, ", ") + " ON " + privilegeTarget(change) +
+		" TO " + quoteRoles(change.Roles)
+	if change.GrantOption {
+		query += " WITH GRANT OPTION"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) RevokePrivileges(ctx context.Context, change domain.PrivilegeChange) error {
+	query := "REVOKE "
+	if change.GrantOption {
+		query += "GRANT OPTION FOR "
+	}
+	query += strings.Join(change.Privileges, ", ") + " ON " + privilegeTarget(change) +
+		" FROM " + quoteRoles(change.Roles)
+	if change.Cascade {
+		query += " CASCADE"
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+// Privileges expands the ACLs of schemas, tables, views and sequences. Objects
+// without an ACL report the default privileges of their owner.
+func (d *DB) Privileges(ctx context.Context, schemas []string) ([]domain.PrivilegeGrant, error) {
+	query := `
+		SELECT 'schema' AS object_type, n.nspname AS schema, n.nspname AS name,
+		       pg_get_userbyid(n.nspowner) AS owner,
+		       coalesce(r.rolname, 'PUBLIC') AS grantee,
+		       array_agg(a.privilege_type ORDER BY a.privilege_type) AS privileges,
+		       array_remove(array_agg(CASE WHEN a.is_grantable THEN a.privilege_type END
+		                              ORDER BY a.privilege_type), NULL) AS grantable
+		FROM pg_namespace n
+		CROSS JOIN LATERAL aclexplode(coalesce(n.nspacl, acldefault('n', n.nspowner))) a
+		LEFT JOIN pg_roles r ON r.oid = a.grantee
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + `
+		GROUP BY 1, 2, 3, 4, 5
+		UNION ALL
+		SELECT CASE WHEN c.relkind = 'S' THEN 'sequence' ELSE 'table' END, n.nspname, c.relname,
+		       pg_get_userbyid(c.relowner),
+		       coalesce(r.rolname, 'PUBLIC'),
+		       array_agg(a.privilege_type ORDER BY a.privilege_type),
+		       array_remove(array_agg(CASE WHEN a.is_grantable THEN a.privilege_type END
+		                              ORDER BY a.privilege_type), NULL)
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		CROSS JOIN LATERAL aclexplode(coalesce(c.relacl,
+		    acldefault(CASE WHEN c.relkind = 'S' THEN 's' ELSE 'r' END::"char", c.relowner))) a
+		LEFT JOIN pg_roles r ON r.oid = a.grantee
+		WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f', 'S') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		GROUP BY 1, 2, 3, 4, 5
+		ORDER BY 2, 1, 3, 5
+	`
+
+	var rows []privilegeRow
+	if err := d.db.SelectContext(ctx, &rows, query, textArray(schemas)); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	grants := make([]domain.PrivilegeGrant, 0, len(rows))
+	for _, row := range rows {
+		grants = append(grants, domain.PrivilegeGrant{
+			ObjectType: row.ObjectType,
+			Schema:     row.Schema,
+			Name:       row.Name,
+			Owner:      row.Owner,
+			Grantee:    row.Grantee,
+			Privileges: row.Privileges,
+			Grantable:  row.Grantable,
+		})
+	}
+	return grants, nil
+}
+
+func roleOptions(attrs domain.RoleAttributes) string {
+	var sb strings.Builder
+	flags := []struct {
+		value *bool
+		name  string
+	}{
+		{attrs.Superuser, "SUPERUSER"},
+		{attrs.Inherit, "INHERIT"},
+		{attrs.CreateRole, "CREATEROLE"},
+		{attrs.CreateDB, "CREATEDB"},
+		{attrs.Login, "LOGIN"},
+		{attrs.Replication, "REPLICATION"},
+		{attrs.BypassRLS, "BYPASSRLS"},
+	}
+	for _, flag := range flags {
+		switch {
+		case flag.value == nil:
+		case *flag.value:
+			sb.WriteString(" " + flag.name)
+		default:
+			sb.WriteString(" NO" + flag.name)
+		}
+	}
+	if attrs.ConnectionLimit != nil {
+		sb.WriteString(" CONNECTION LIMIT " + strconv.Itoa(*attrs.ConnectionLimit))
+	}
+	if attrs.ValidUntil != nil {
+		sb.WriteString(" VALID UNTIL " + pgclient.QuoteLiteral(*attrs.ValidUntil))
+	}
+
+	return sb.String()
+}
+
+func privilegeTarget(change domain.PrivilegeChange) string {
+	switch {
+	case change.ObjectType == "schema":
+		return "SCHEMA " + pgclient.QuoteIdent(change.Schema)
+	case len(change.Objects) == 0:
+		return "ALL " + strings.ToUpper(change.ObjectType) + "S IN SCHEMA " + pgclient.QuoteIdent(change.Schema)
+	}
+
+	objects := make([]string, 0, len(change.Objects))
+	for _, object := range change.Objects {
+		objects = append(objects, pgclient.QuoteQualified(change.Schema, object))
+	}
+	return strings.ToUpper(change.ObjectType) + " " + strings.Join(objects, ", ")
+}
+
+// quoteRoles quotes role names, keeping the PUBLIC pseudo-role as a keyword.
+func quoteRoles(roles []string) string {
+	quoted := make([]string, 0, len(roles))
+	for _, role := range roles {
+		if strings.EqualFold(role, "public") {
+			quoted = append(quoted, "PUBLIC")
+			continue
+		}
+		quoted = append(quoted, pgclient.QuoteIdent(role))
+	}
+	return strings.Join(quoted, ", ")
+}
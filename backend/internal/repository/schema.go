@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+
+	"github.com/lib/pq"
+)
+
+// schemaFilter limits a catalog query to the schemas passed as $1, or to all
+// user schemas when $1 is empty. %[1]s is the pg_namespace alias.
+const schemaFilter = `((cardinality($1::text[]) = 0
+		AND %[1]s.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND %[1]s.nspname NOT LIKE 'pg\_%%') OR %[1]s.nspname = ANY($1))`
+
+// textArray binds a string slice as a text[] parameter, nil binding an empty array.
+func textArray(values []string) any {
+	if values == nil {
+		values = []string{}
+	}
+
+	return pq.Array(values)
+}
+
+type snapshotColumnRow struct {
+	Schema string `db:"schema"`
+	Table  string `db:"table_name"`
+	domain.SnapshotColumn
+}
+
+type snapshotConstraintRow struct {
+	Schema string `db:"schema"`
+	Table  string `db:"table_name"`
+	domain.SnapshotConstraint
+}
+
+type snapshotIndexRow struct {
+	Schema string `db:"schema"`
+	Table  string `db:"table_name"`
+	domain.SnapshotIndex
+}
+
+type snapshotEnumRow struct {
+	Schema string         `db:"schema"`
+	Name   string         `db:"name"`
+	Labels pq.StringArray `db:"labels"`
+}
+
+// SchemaSnapshot captures tables, columns, constraints, indexes, views, functions,
+// sequences, enums and triggers of the given schemas, or of all user schemas.
+func (d *DB) SchemaSnapshot(ctx context.Context, schemas []string) (domain.SchemaSnapshot, error) {
+	tablesQuery := `
+		SELECT n.nspname AS schema,
+		       c.relname AS name,
+		       CASE WHEN c.relkind = 'p' THEN pg_get_partkeydef(c.oid) END AS partition_key,
+		       pn.nspname AS parent_schema,
+		       p.relname AS parent,
+		       CASE WHEN c.relispartition THEN pg_get_expr(c.relpartbound, c.oid) END AS partition_bound
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_inherits i ON c.relispartition AND i.inhrelid = c.oid
+		LEFT JOIN pg_class p ON p.oid = i.inhparent
+		LEFT JOIN pg_namespace pn ON pn.oid = p.relnamespace
+		WHERE c.relkind IN ('r', 'p') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "c.oid") + `
+		ORDER BY 1, 2
+	`
+
+	columnsQuery := `
+		SELECT n.nspname AS schema,
+		       c.relname AS table_name,
+		       a.attname AS name,
+		       format_type(a.atttypid, a.atttypmod) AS type,
+		       NOT a.attnotnull AS nullable,
+		       pg_get_expr(ad.adbin, ad.adrelid) AS default,
+		       CASE a.attidentity WHEN 'a' THEN 'always' WHEN 'd' THEN 'by default' ELSE '' END AS identity,
+		       a.attgenerated <> '' AS generated,
+		       a.attnum AS position
+		FROM pg_attribute a
+		JOIN pg_class c ON c.oid = a.attrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_attrdef ad ON ad.adrelid = a.attrelid AND ad.adnum = a.attnum
+		WHERE c.relkind IN ('r', 'p') AND a.attnum > 0 AND NOT a.attisdropped
+		  AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		ORDER BY 1, 2, a.attnum
+	`
+
+	constraintsQuery := `
+		SELECT n.nspname AS schema,
+		       c.relname AS table_name,
+		       con.conname AS name,
+		       con.contype::text AS type,
+		       pg_get_constraintdef(con.oid) AS definition
+		FROM pg_constraint con
+		JOIN pg_class c ON c.oid = con.conrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p') AND con.conislocal AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		ORDER BY 1, 2, 3
+	`
+
+	indexesQuery := `
+		SELECT n.nspname AS schema,
+		       c.relname AS table_name,
+		       ic.relname AS name,
+		       pg_get_indexdef(i.indexrelid) AS definition
+		FROM pg_index i
+		JOIN pg_class ic ON ic.oid = i.indexrelid
+		JOIN pg_class c ON c.oid = i.indrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND NOT EXISTS (SELECT 1 FROM pg_constraint con
+		                  WHERE con.conindid = i.indexrelid AND con.contype IN ('p', 'u', 'x'))
+		  AND NOT EXISTS (SELECT 1 FROM pg_inherits ih WHERE ih.inhrelid = i.indexrelid)
+		ORDER BY 1, 2, 3
+	`
+
+	viewsQuery := `
+		SELECT n.nspname AS schema,
+		       c.relname AS name,
+		       c.relkind = 'm' AS materialized,
+		       pg_get_viewdef(c.oid) AS definition
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('v', 'm') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "c.oid") + `
+		ORDER BY 1, 2
+	`
+
+	functionsQuery := `
+		SELECT n.nspname AS schema,
+		       p.proname AS name,
+		       pg_get_function_identity_arguments(p.oid) AS arguments,
+		       CASE p.prokind WHEN 'p' THEN 'procedure' ELSE 'function' END AS kind,
+		       pg_get_functiondef(p.oid) AS definition
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE p.prokind IN ('f', 'p', 'w') AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "p.oid") + `
+		ORDER BY 1, 2, 3
+	`
+
+	sequencesQuery := `
+		SELECT s.schemaname AS schema,
+		       s.sequencename AS name,
+		       s.data_type::text AS data_type,
+		       s.start_value AS start,
+		       s.increment_by AS increment,
+		       s.min_value AS min,
+		       s.max_value AS max,
+		       s.cycle AS cycle
+		FROM pg_sequences s
+		JOIN pg_namespace n ON n.nspname = s.schemaname
+		JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND NOT EXISTS (SELECT 1 FROM pg_depend dep WHERE dep.objid = c.oid AND dep.deptype IN ('i', 'e'))
+		ORDER BY 1, 2
+	`
+
+	enumsQuery := `
+		SELECT n.nspname AS schema,
+		       t.typname AS name,
+		       array_agg(e.enumlabel ORDER BY e.enumsortorder) AS labels
+		FROM pg_type t
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "t.oid") + `
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`
+
+	triggersQuery := `
+		SELECT n.nspname AS schema,
+		       c.relname AS table_name,
+		       t.tgname AS name,
+		       pg_get_triggerdef(t.oid) AS definition
+		FROM pg_trigger t
+		JOIN pg_class c ON c.oid = t.tgrelid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE NOT t.tgisinternal AND t.tgparentid = 0 AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		ORDER BY 1, 2, 3
+	`
+
+	filter := textArray(schemas)
+
+	snapshot := domain.SchemaSnapshot{
+		Tables:    []domain.SnapshotTable{},
+		Views:     []domain.SnapshotView{},
+		Functions: []domain.SnapshotFunction{},
+		Sequences: []domain.SnapshotSequence{},
+		Enums:     []domain.SnapshotEnum{},
+		Triggers:  []domain.SnapshotTrigger{},
+	}
+
+	var (
+		columns     []snapshotColumnRow
+		constraints []snapshotConstraintRow
+		indexes     []snapshotIndexRow
+		enums       []snapshotEnumRow
+	)
+
+	selects := []struct {
+		what  string
+		dest  any
+		query string
+	}{
+		{"tables", &snapshot.Tables, tablesQuery},
+		{"columns", &columns, columnsQuery},
+		{"constraints", &constraints, constraintsQuery},
+		{"indexes", &indexes, indexesQuery},
+		{"views", &snapshot.Views, viewsQuery},
+		{"functions", &snapshot.Functions, functionsQuery},
+		{"sequences", &snapshot.Sequences, sequencesQuery},
+		{"enums", &enums, enumsQuery},
+		{"triggers", &snapshot.Triggers, triggersQuery},
+	}
+
+	for _, s := range selects {
+		if err := d.db.SelectContext(ctx, s.dest, s.query, filter); err != nil {
+			return domain.SchemaSnapshot{}, fmt.Errorf("postgres: %s: %w", s.what, err)
+		}
+	}
+
+	tables := make(map[string]*domain.SnapshotTable, len(snapshot.Tables))
+	for i := range snapshot.Tables {
+		table := &snapshot.Tables[i]
+		table.Columns = []domain.SnapshotColumn{}
+		table.Constraints = []domain.SnapshotConstraint{}
+		table.Indexes = []domain.SnapshotIndex{}
+		tables[table.Schema+"."+table.Name] = table
+	}
+
+	for _, row := range columns {
+		if table, ok := tables[row.Schema+"."+row.Table]; ok {
+			table.Columns = append(table.Columns, row.SnapshotColumn)
+		}
+	}
+	for _, row := range constraints {
+		if table, ok := tables[row.Schema+"."+row.Table]; ok {
+			table.Constraints = append(table.Constraints, row.SnapshotConstraint)
+		}
+	}
+	for _, row := range indexes {
+		if table, ok := tables[row.Schema+"."+row.Table]; ok {
+			table.Indexes = append(table.Indexes, row.SnapshotIndex)
+		}
+	}
+	for _, row := range enums {
+		snapshot.Enums = append(snapshot.Enums, domain.SnapshotEnum{
+			Schema: row.Schema,
+			Name:   row.Name,
+			Labels: row.Labels,
+		})
+	}
+
+	return snapshot, nil
+}
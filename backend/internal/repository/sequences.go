@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Sequences lists sequences with the column owning them (serial and identity
+// columns) and the largest value used in that column. With table set only the
+// sequences owned by that table are returned.
+func (d *DB) Sequences(ctx context.Context, schemas []string, table string) ([]domain.Sequence, error) {
+	sequences, err := listSequences(ctx, d.db, schemas, table)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sequences {
+		seq := &sequences[i]
+		if seq.OwnerTable == nil {
+			continue
+		}
+
+		query := fmt.Sprintf("SELECT max(%s)::bigint FROM %s",
+			pgclient.QuoteIdent(*seq.OwnerColumn), pgclient.QuoteQualified(*seq.OwnerSchema, *seq.OwnerTable))
+		if err = d.db.GetContext(ctx, &seq.MaxUsed, query); err != nil {
+			return nil, fmt.Errorf("postgres: %s.%s: %w", seq.Schema, seq.Name, err)
+		}
+
+		next := seq.StartValue
+		if seq.LastValue != nil {
+			next = *seq.LastValue + seq.Increment
+		}
+		seq.Behind = seq.MaxUsed != nil && seq.Increment > 0 && next <= *seq.MaxUsed
+	}
+
+	return sequences, nil
+}
+
+// ResyncSequences sets every owned ascending sequence so that its next value is
+// max(column)+1, or its start value when the column is empty. Owning tables are
+// locked against writes until all sequences are moved.
+func (d *DB) ResyncSequences(ctx context.Context, schemas []string, table string) ([]domain.SequenceResynced, error) {
+	tx, err := d.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	sequences, err := listSequences(ctx, tx, schemas, table)
+	if err != nil {
+		return nil, err
+	}
+
+	resynced := []domain.SequenceResynced{}
+	for _, seq := range sequences {
+		if seq.OwnerTable == nil || seq.Increment < 0 {
+			continue
+		}
+		tableName := pgclient.QuoteQualified(*seq.OwnerSchema, *seq.OwnerTable)
+
+		if _, err = tx.ExecContext(ctx, "LOCK TABLE "+tableName+" IN EXCLUSIVE MODE"); err != nil {
+			return nil, fmt.Errorf("postgres: lock %s: %w", tableName, err)
+		}
+
+		query := fmt.Sprintf("SELECT setval($1::regclass, coalesce(max(%s)::bigint + 1, $2), false) FROM %s",
+			pgclient.QuoteIdent(*seq.OwnerColumn), tableName)
+		var next int64
+		if err = tx.GetContext(ctx, &next, query, pgclient.QuoteQualified(seq.Schema, seq.Name), seq.StartValue); err != nil {
+			return nil, fmt.Errorf("postgres: %s.%s: %w", seq.Schema, seq.Name, err)
+		}
+
+		resynced = append(resynced, domain.SequenceResynced{
+			Schema:    seq.Schema,
+			Name:      seq.Name,
+			Table:     *seq.OwnerTable,
+			Column:    *seq.OwnerColumn,
+			LastValue: seq.LastValue,
+			NextValue: next,
+		})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("postgres: commit: %w", err)
+	}
+
+	return resynced, nil
+}
+
+func listSequences(ctx context.Context, q sqlx.QueryerContext, schemas []string, table string) ([]domain.Sequence, error) {
+	query := `
+		SELECT s.schemaname AS schema,
+		       s.sequencename AS name,
+		       format_type(s.data_type, NULL) AS data_type,
+		       s.start_value,
+		       s.increment_by AS increment,
+		       s.last_value,
+		       tn.nspname AS owner_schema,
+		       t.relname AS owner_table,
+		       a.attname AS owner_column
+		FROM pg_sequences s
+		JOIN pg_namespace n ON n.nspname = s.schemaname
+		JOIN pg_class c ON c.relnamespace = n.oid AND c.relname = s.sequencename
+		LEFT JOIN pg_depend dep ON dep.classid = 'pg_class'::regclass AND dep.objid = c.oid
+		     AND dep.refclassid = 'pg_class'::regclass AND dep.refobjsubid > 0 AND dep.deptype IN ('a', 'i')
+		LEFT JOIN pg_class t ON t.oid = dep.refobjid
+		LEFT JOIN pg_namespace tn ON tn.oid = t.relnamespace
+		LEFT JOIN pg_attribute a ON a.attrelid = dep.refobjid AND a.attnum = dep.refobjsubid
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + ` AND ($2 = '' OR t.relname = $2)
+		ORDER BY 1, 2
+	`
+
+	sequences := []domain.Sequence{}
+	if err := sqlx.SelectContext(ctx, q, &sequences, query, textArray(schemas), table); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return sequences, nil
+}
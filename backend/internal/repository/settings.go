@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type settingRow struct {
+	domain.Setting
+	EnumVals pq.StringArray `db:"enumvals"`
+}
+
+// Settings lists pg_settings ordered by category, or a single parameter when name is set.
+func (d *DB) Settings(ctx context.Context, name string) ([]domain.Setting, error) {
+	query := `
+		SELECT name, category, setting, unit, vartype, context, source, sourcefile,
+		       boot_val, reset_val, min_val, max_val, enumvals, short_desc, pending_restart
+		FROM pg_settings
+		WHERE $1 = '' OR name = $1
+		ORDER BY category, name
+	`
+
+	var rows []settingRow
+	if err := d.db.SelectContext(ctx, &rows, query, name); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+
+	settings := make([]domain.Setting, 0, len(rows))
+	for _, row := range rows {
+		setting := row.Setting
+		setting.EnumValues = []string(row.EnumVals)
+		if setting.EnumValues == nil {
+			setting.EnumValues = []string{}
+		}
+		settings = append(settings, setting)
+	}
+
+	return settings, nil
+}
+
+// PendingSettings lists the last entry of every parameter in the configuration
+// files that is not applied to the running server.
+func (d *DB) PendingSettings(ctx context.Context) ([]domain.PendingSetting, error) {
+	query := `
+		SELECT name, current, pending, sourcefile, requires_restart, error FROM (
+		    SELECT DISTINCT ON (f.name) f.name, s.setting AS current, f.setting AS pending, f.sourcefile,
+		           coalesce(s.context = 'postmaster', false) AS requires_restart, f.error, f.applied
+		    FROM pg_file_settings f
+		    LEFT JOIN pg_settings s ON s.name = f.name
+		    ORDER BY f.name, f.seqno DESC
+		) last
+		WHERE NOT applied
+		ORDER BY name
+	`
+
+	pending := []domain.PendingSetting{}
+	if err := d.db.SelectContext(ctx, &pending, query); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return pending, nil
+}
+
+// listSettings are the parameters taking a list of values. ALTER SYSTEM stores a
+// single literal as one element, so their items are passed as separate literals.
+var listSettings = map[string]bool{
+	"datestyle":                 true,
+	"listen_addresses":          true,
+	"local_preload_libraries":   true,
+	"log_destination":           true,
+	"search_path":               true,
+	"session_preload_libraries": true,
+	"shared_preload_libraries":  true,
+	"temp_tablespaces":          true,
+	"unix_socket_directories":   true,
+	"wal_consistency_checking":  true,
+}
+
+// settingValue quotes the value of a parameter, splitting list values on commas.
+func settingValue(name, value string) string {
+	if !listSettings[strings.ToLower(name)] {
+		return pgclient.QuoteLiteral(value)
+	}
+
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, pgclient.QuoteLiteral(item))
+		}
+	}
+	if len(items) == 0 {
+		return "''"
+	}
+	return strings.Join(items, ", ")
+}
+
+// AlterSystem writes a parameter to postgresql.auto.conf, or removes it when value
+// is nil, and reloads the configuration.
+func (d *DB) AlterSystem(ctx context.Context, name string, value *string) error {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = pgclient.QuoteIdent(part)
+	}
+
+	query := "ALTER SYSTEM "
+	if value != nil {
+		query += "SET " + strings.Join(parts, ".") + " = " + settingValue(name, *value)
+	} else {
+		query += "RESET " + strings.Join(parts, ".")
+	}
+
+	// ALTER SYSTEM cannot run inside a transaction block, the statements are sent on their own.
+	if _, err := d.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	if _, err := d.db.ExecContext(ctx, "SELECT pg_reload_conf()"); err != nil {
+		return fmt.Errorf("postgres: reload configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import "testing"
+
+func TestSettingValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "work_mem", value: "64MB", want: "'64MB'"},
+		{name: "application_name", value: "a, b", want: "'a, b'"},
+		{name: "shared_preload_libraries", value: "pg_stat_statements, auto_explain", want: "'pg_stat_statements', 'auto_explain'"},
+		{name: "shared_preload_libraries", value: "pg_stat_statements", want: "'pg_stat_statements'"},
+		{name: "search_path", value: `"$user",public`, want: `'"$user"', 'public'`},
+		{name: "DateStyle", value: "ISO, DMY", want: "'ISO', 'DMY'"},
+		{name: "shared_preload_libraries", value: "", want: "''"},
+	}
+
+	for _, tt := range tests {
+		if got := settingValue(tt.name, tt.value); got != tt.want {
+			t.Errorf("settingValue(%q, %q) = %s, want %s", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
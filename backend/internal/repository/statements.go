@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+)
+
+// StatementsSchema returns the schema pg_stat_statements is installed in, or an
+// empty string when the extension is not installed in the current database.
+func (d *DB) StatementsSchema(ctx context.Context) (string, error) {
+	query := `
+		SELECT n.nspname FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname = 'pg_stat_statements'
+	`
+
+	var schema string
+	err := d.db.GetContext(ctx, &schema, query)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("postgres: %w", err)
+	}
+	return schema, nil
+}
+
+// Statements reads the statements of the current database from pg_stat_statements
+// installed in schema. Before version 1.9 of the extension only top-level
+// statements are tracked and the toplevel column is missing.
+func (d *DB) Statements(ctx context.Context, schema string) ([]domain.QueryStat, error) {
+	view := pgclient.QuoteQualified(schema, "pg_stat_statements")
+
+	var hasTopLevel bool
+	err := d.db.GetContext(ctx, &hasTopLevel, `
+		SELECT EXISTS (
+			SELECT 1 FROM pg_attribute
+			WHERE attrelid = $1::regclass AND attname = 'toplevel' AND NOT attisdropped
+		)
+	`, view)
+	if err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	topLevel := "true AS toplevel"
+	if hasTopLevel {
+		topLevel = "s.toplevel"
+	}
+
+	query := `
+		SELECT coalesce(s.queryid, 0) AS queryid,
+		       coalesce(pg_get_userbyid(s.userid), '') AS user_name,
+		       ` + topLevel + `,
+		       s.query,
+		       s.calls,
+		       s.total_exec_time AS total_time_ms,
+		       s.mean_exec_time AS mean_time_ms,
+		       s.rows,
+		       s.shared_blks_hit,
+		       s.shared_blks_read,
+		       s.shared_blks_dirtied,
+		       s.shared_blks_written
+		FROM ` + view + ` s
+		JOIN pg_database db ON db.oid = s.dbid
+		WHERE db.datname = current_database()
+	`
+
+	stats := []domain.QueryStat{}
+	if err := d.db.SelectContext(ctx, &stats, query); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return stats, nil
+}
+
+// ResetStatements discards the statistics gathered for the current database only.
+func (d *DB) ResetStatements(ctx context.Context, schema string) error {
+	query := "SELECT " + pgclient.QuoteQualified(schema, "pg_stat_statements_reset") +
+		"(0, (SELECT oid FROM pg_database WHERE datname = current_database()), 0)"
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strings"
+)
+
+// TableStats lists the statistics and sizes of the tables of the given schemas,
+// optionally of a single table, largest first. The bloat estimate compares the
+// pages of the table with the pages its rows would need at the table's fillfactor.
+func (d *DB) TableStats(ctx context.Context, schemas []string, table string) ([]domain.TableStats, error) {
+	query := `
+		SELECT n.nspname AS schema, c.relname AS table_name,
+		       coalesce(s.n_live_tup, 0) AS live_tuples,
+		       coalesce(s.n_dead_tup, 0) AS dead_tuples,
+		       coalesce(s.n_dead_tup::float8 / nullif(s.n_live_tup + s.n_dead_tup, 0), 0) AS dead_ratio,
+		       coalesce(s.n_mod_since_analyze, 0) AS modifications_since_analyze,
+		       coalesce(s.seq_scan, 0) AS seq_scans,
+		       coalesce(s.seq_tup_read, 0) AS seq_tuples_read,
+		       coalesce(s.idx_scan, 0) AS index_scans,
+		       coalesce(s.idx_tup_fetch, 0) AS index_tuples_fetched,
+		       s.last_vacuum, s.last_autovacuum, s.last_analyze, s.last_autoanalyze,
+		       coalesce(s.vacuum_count, 0) AS vacuum_count,
+		       coalesce(s.autovacuum_count, 0) AS autovacuum_count,
+		       coalesce(s.analyze_count, 0) AS analyze_count,
+		       coalesce(s.autoanalyze_count, 0) AS autoanalyze_count,
+		       pg_relation_size(c.oid) AS table_bytes,
+		       pg_indexes_size(c.oid) AS indexes_bytes,
+		       coalesce(pg_total_relation_size(nullif(c.reltoastrelid, 0)), 0) AS toast_bytes,
+		       pg_total_relation_size(c.oid) AS total_bytes,
+		       CASE WHEN c.reltuples >= 0 AND w.width IS NOT NULL THEN
+		           greatest(0, c.relpages - ceil(c.reltuples * (w.width + 24)
+		               / ((current_setting('block_size')::int - 24) * w.fillfactor / 100.0)))::bigint
+		           * current_setting('block_size')::bigint
+		       END AS bloat_bytes
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN pg_stat_user_tables s ON s.relid = c.oid
+		LEFT JOIN LATERAL (
+		    SELECT sum(st.avg_width) AS width,
+		           coalesce((SELECT o.option_value::int FROM pg_options_to_table(c.reloptions) o
+		                     WHERE o.option_name = 'fillfactor'), 100) AS fillfactor
+		    FROM pg_stats st
+		    WHERE st.schemaname = n.nspname AND st.tablename = c.relname
+		) w ON true
+		WHERE c.relkind IN ('r', 'm') AND ` + fmt.Sprintf(schemaFilter, "n") + ` AND ($2 = '' OR c.relname = $2)
+		ORDER BY total_bytes DESC, 1, 2
+	`
+
+	stats := []domain.TableStats{}
+	if err := d.db.SelectContext(ctx, &stats, query, textArray(schemas), table); err != nil {
+		return nil, fmt.Errorf("postgres: %w", err)
+	}
+	return stats, nil
+}
+
+func (d *DB) VacuumTable(ctx context.Context, schema, table string, opts domain.VacuumOptions) error {
+	var options []string
+	if opts.Full {
+		options = append(options, "FULL")
+	}
+	if opts.Freeze {
+		options = append(options, "FREEZE")
+	}
+	if opts.Analyze {
+		options = append(options, "ANALYZE")
+	}
+
+	query := "VACUUM "
+	if len(options) > 0 {
+		query += "(" + strings.Join(options, ", ") + ") "
+	}
+	query += pgclient.QuoteQualified(schema, table)
+
+	// VACUUM cannot run inside a transaction block, the statement is sent on its own.
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) AnalyzeTable(ctx context.Context, schema, table string) error {
+	_, err := d.db.ExecContext(ctx, "ANALYZE "+pgclient.QuoteQualified(schema, table))
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"strings"
+)
+
+type typeAttributeRow struct {
+	Schema string `db:"schema"`
+	Parent string `db:"parent"`
+	domain.TypeAttribute
+}
+
+type domainCheckRow struct {
+	Schema string `db:"schema"`
+	Parent string `db:"parent"`
+	domain.DomainCheck
+}
+
+// Types lists enums with their labels, standalone composite types with their
+// attributes and domains with their CHECK constraints.
+func (d *DB) Types(ctx context.Context, schemas []string) (domain.Types, error) {
+	enumsQuery := `
+		SELECT n.nspname AS schema,
+		       t.typname AS name,
+		       array_agg(e.enumlabel ORDER BY e.enumsortorder) AS labels
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_enum e ON e.enumtypid = t.oid
+		WHERE ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "t.oid") + `
+		GROUP BY 1, 2
+		ORDER BY 1, 2
+	`
+
+	compositesQuery := `
+		SELECT n.nspname AS schema, t.typname AS name
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_class c ON c.oid = t.typrelid
+		WHERE t.typtype = 'c' AND c.relkind = 'c' AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "t.oid") + `
+		ORDER BY 1, 2
+	`
+
+	attributesQuery := `
+		SELECT n.nspname AS schema, t.typname AS parent,
+		       a.attname AS name, format_type(a.atttypid, a.atttypmod) AS type
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		JOIN pg_class c ON c.oid = t.typrelid
+		JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+		WHERE t.typtype = 'c' AND c.relkind = 'c' AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		ORDER BY 1, 2, a.attnum
+	`
+
+	domainsQuery := `
+		SELECT n.nspname AS schema, t.typname AS name,
+		       format_type(t.typbasetype, t.typtypmod) AS base_type,
+		       t.typdefault AS default, t.typnotnull AS not_null
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE t.typtype = 'd' AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		  AND ` + fmt.Sprintf(notExtensionMember, "t.oid") + `
+		ORDER BY 1, 2
+	`
+
+	checksQuery := `
+		SELECT n.nspname AS schema, t.typname AS parent,
+		       con.conname AS name, pg_get_expr(con.conbin, 0, true) AS expression
+		FROM pg_constraint con
+		JOIN pg_type t ON t.oid = con.contypid
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		WHERE con.contype = 'c' AND ` + fmt.Sprintf(schemaFilter, "n") + `
+		ORDER BY 1, 2, 3
+	`
+
+	types := domain.Types{
+		Enums:      []domain.EnumType{},
+		Composites: []domain.CompositeType{},
+		Domains:    []domain.DomainType{},
+	}
+
+	var (
+		enums      []snapshotEnumRow
+		attributes []typeAttributeRow
+		checks     []domainCheckRow
+	)
+
+	selects := []struct {
+		what  string
+		dest  any
+		query string
+	}{
+		{"enums", &enums, enumsQuery},
+		{"composite types", &types.Composites, compositesQuery},
+		{"composite attributes", &attributes, attributesQuery},
+		{"domains", &types.Domains, domainsQuery},
+		{"domain checks", &checks, checksQuery},
+	}
+	for _, s := range selects {
+		if err := d.db.SelectContext(ctx, s.dest, s.query, textArray(schemas)); err != nil {
+			return domain.Types{}, fmt.Errorf("postgres: %s: %w", s.what, err)
+		}
+	}
+
+	for _, row := range enums {
+		types.Enums = append(types.Enums, domain.EnumType{Schema: row.Schema, Name: row.Name, Labels: row.Labels})
+	}
+	for i := range types.Composites {
+		t := &types.Composites[i]
+		t.Attributes = []domain.TypeAttribute{}
+		for _, row := range attributes {
+			if row.Schema == t.Schema && row.Parent == t.Name {
+				t.Attributes = append(t.Attributes, row.TypeAttribute)
+			}
+		}
+	}
+	for i := range types.Domains {
+		t := &types.Domains[i]
+		t.Checks = []domain.DomainCheck{}
+		for _, row := range checks {
+			if row.Schema == t.Schema && row.Parent == t.Name {
+				t.Checks = append(t.Checks, row.DomainCheck)
+			}
+		}
+	}
+
+	return types, nil
+}
+
+func (d *DB) CreateEnum(ctx context.Context, enum domain.EnumCreate) error {
+	labels := make([]string, 0, len(enum.Labels))
+	for _, label := range enum.Labels {
+		labels = append(labels, pgclient.QuoteLiteral(label))
+	}
+	query := fmt.Sprintf("CREATE TYPE %s AS ENUM (%s)",
+		pgclient.QuoteQualified(enum.Schema, enum.Name), strings.Join(labels, ", "))
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) AddEnumValue(ctx context.Context, schema, name string, value domain.EnumValueAdd) error {
+	query := "ALTER TYPE " + pgclient.QuoteQualified(schema, name) + " ADD VALUE "
+	if value.IfNotExists {
+		query += "IF NOT EXISTS "
+	}
+	query += pgclient.QuoteLiteral(value.Value)
+	switch {
+	case value.Before != "":
+		query += " BEFORE " + pgclient.QuoteLiteral(value.Before)
+	case value.After != "":
+		query += " AFTER " + pgclient.QuoteLiteral(value.After)
+	}
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) RenameEnumValue(ctx context.Context, schema, name, from, to string) error {
+	query := fmt.Sprintf("ALTER TYPE %s RENAME VALUE %s TO %s",
+		pgclient.QuoteQualified(schema, name), pgclient.QuoteLiteral(from), pgclient.QuoteLiteral(to))
+
+	_, err := d.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
+
+func (d *DB) CreateDomain(ctx context.Context, create domain.DomainCreate) error {
+	var sb strings.Builder
+	sb.WriteString("CREATE DOMAIN " + pgclient.QuoteQualified(create.Schema, create.Name) + " AS " + create.BaseType)
+	if create.Default != nil {
+		sb.WriteString(" DEFAULT " + *create.Default)
+	}
+	if create.NotNull {
+		sb.WriteString(" NOT NULL")
+	}
+	for _, check := range create.Checks {
+		if check.Name != "" {
+			sb.WriteString(" CONSTRAINT " + pgclient.QuoteIdent(check.Name))
+		}
+		sb.WriteString(" CHECK (" + check.Expression + ")")
+	}
+
+	_, err := d.db.ExecContext(ctx, sb.String())
+	if err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	return nil
+}
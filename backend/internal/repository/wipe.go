@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// notExtensionMember filters out objects installed by extensions; %s is the oid column.
+const notExtensionMember = `NOT EXISTS (SELECT 1 FROM pg_depend e WHERE e.objid = %s AND e.deptype = 'e')`
+
+// wipeTarget is an object of the public schema that DeleteAllTables drops.
+type wipeTarget struct {
+	Keyword string `db:"keyword"`
+	Name    string `db:"name"`
+	Args    string `db:"args"`
+}
+
+type wipeStep struct {
+	enabled bool
+	query   string
+	removed *[]string
+}
+
+// WipePreview collects the tables of the public schema together with the views
+// and sequences that DROP ... CASCADE removes with them.
+func (d *DB) WipePreview(ctx context.Context) (domain.WipePreview, error) {
+	return wipePreview(ctx, d.db)
+}
+
+func wipePreview(ctx context.Context, q sqlx.QueryerContext) (domain.WipePreview, error) {
+	tablesQuery := `
+		SELECT c.relname AS name,
+		       GREATEST(c.reltuples, 0)::bigint AS estimated_rows
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p')
+		ORDER BY c.relname
+	`
+
+	viewsQuery := `
+		WITH RECURSIVE deps AS (
+			SELECT c.oid
+			FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace
+			WHERE n.nspname = 'public' AND c.relkind IN ('r', 'p')
+			UNION
+			SELECT rw.ev_class
+			FROM pg_depend dep
+			JOIN pg_rewrite rw ON rw.oid = dep.objid
+			JOIN deps ON deps.oid = dep.refobjid
+			WHERE dep.classid = 'pg_rewrite'::regclass
+			  AND dep.refclassid = 'pg_class'::regclass
+			  AND rw.ev_class <> dep.refobjid
+		)
+		SELECT n.nspname AS schema,
+		       c.relname AS name,
+		       CASE c.relkind WHEN 'm' THEN 'materialized view' ELSE 'view' END AS kind
+		FROM deps
+		JOIN pg_class c ON c.oid = deps.oid
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('v', 'm')
+		ORDER BY 1, 2
+	`
+
+	sequencesQuery := `
+		SELECT DISTINCT sn.nspname AS schema,
+		       s.relname AS name,
+		       'sequence' AS kind
+		FROM pg_class s
+		JOIN pg_namespace sn ON sn.oid = s.relnamespace
+		JOIN pg_depend dep ON dep.objid = s.oid
+		                  AND dep.classid = 'pg_class'::regclass
+		                  AND dep.refclassid = 'pg_class'::regclass
+		                  AND dep.deptype IN ('a', 'i')
+		JOIN pg_class t ON t.oid = dep.refobjid
+		JOIN pg_namespace tn ON tn.oid = t.relnamespace
+		WHERE s.relkind = 'S' AND tn.nspname = 'public' AND t.relkind IN ('r', 'p')
+		ORDER BY 1, 2
+	`
+
+	preview := domain.WipePreview{
+		Tables:    []domain.WipeTable{},
+		Views:     []domain.WipeObject{},
+		Sequences: []domain.WipeObject{},
+	}
+	if err := sqlx.SelectContext(ctx, q, &preview.Tables, tablesQuery); err != nil {
+		return domain.WipePreview{}, fmt.Errorf("postgres: tables: %w", err)
+	}
+	if err := sqlx.SelectContext(ctx, q, &preview.Views, viewsQuery); err != nil {
+		return domain.WipePreview{}, fmt.Errorf("postgres: dependent views: %w", err)
+	}
+	if err := sqlx.SelectContext(ctx, q, &preview.Sequences, sequencesQuery); err != nil {
+		return domain.WipePreview{}, fmt.Errorf("postgres: sequences: %w", err)
+	}
+
+	for _, table := range preview.Tables {
+		preview.EstimatedRows += table.EstimatedRows
+	}
+
+	return preview, nil
+}
+
+// DeleteAllTables drops every table of the public schema and, depending on opts,
+// its views, materialized views, sequences, functions and types, all in one transaction.
+func (d *DB) DeleteAllTables(ctx context.Context, opts domain.WipeOptions) (domain.WipeReport, error) {
+	relations := `
+		SELECT '%s' AS keyword, c.relname AS name, '' AS args
+		FROM pg_class c
+		JOIN pg_namespace n ON n.oid = c.relnamespace
+		WHERE n.nspname = 'public' AND c.relkind IN (%s) AND ` + fmt.Sprintf(notExtensionMember, "c.oid") + `
+		ORDER BY c.relname
+	`
+
+	functionsQuery := `
+		SELECT CASE p.prokind WHEN 'p' THEN 'PROCEDURE' WHEN 'a' THEN 'AGGREGATE' ELSE 'FUNCTION' END AS keyword,
+		       p.proname AS name,
+		       '(' || pg_get_function_identity_arguments(p.oid) || ')' AS args
+		FROM pg_proc p
+		JOIN pg_namespace n ON n.oid = p.pronamespace
+		WHERE n.nspname = 'public' AND ` + fmt.Sprintf(notExtensionMember, "p.oid") + `
+		ORDER BY p.proname
+	`
+
+	typesQuery := `
+		SELECT CASE t.typtype WHEN 'd' THEN 'DOMAIN' ELSE 'TYPE' END AS keyword,
+		       t.typname AS name,
+		       '' AS args
+		FROM pg_type t
+		JOIN pg_namespace n ON n.oid = t.typnamespace
+		LEFT JOIN pg_class c ON c.oid = t.typrelid
+		WHERE n.nspname = 'public'
+		  AND (t.typtype IN ('e', 'd', 'r') OR (t.typtype = 'c' AND c.relkind = 'c'))
+		  AND ` + fmt.Sprintf(notExtensionMember, "t.oid") + `
+		ORDER BY t.typname
+	`
+
+	report := domain.WipeReport{
+		Tables:            []string{},
+		Views:             []string{},
+		MaterializedViews: []string{},
+		Sequences:         []string{},
+		Types:             []string{},
+		Functions:         []string{},
+		Cascaded:          []domain.WipeObject{},
+	}
+
+	// Tables go before functions and types, so that dropping those does not
+	// cascade to columns, defaults or triggers of tables that are removed anyway.
+	steps := []wipeStep{
+		{enabled: opts.MaterializedViews, query: fmt.Sprintf(relations, "MATERIALIZED VIEW", "'m'"), removed: &report.MaterializedViews},
+		{enabled: opts.Views, query: fmt.Sprintf(relations, "VIEW", "'v'"), removed: &report.Views},
+		{enabled: true, query: fmt.Sprintf(relations, "TABLE", "'r', 'p'"), removed: &report.Tables},
+		{enabled: opts.Sequences, query: fmt.Sprintf(relations, "SEQUENCE", "'S'"), removed: &report.Sequences},
+		{enabled: opts.Functions, query: functionsQuery, removed: &report.Functions},
+		{enabled: opts.Types, query: typesQuery, removed: &report.Types},
+	}
+
+	tx, err := d.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.WipeReport{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback() //nolint:errcheck // rollback after commit is a no-op
+
+	preview, err := wipePreview(ctx, tx)
+	if err != nil {
+		return domain.WipeReport{}, err
+	}
+
+	if _, err = tx.ExecContext(ctx, "SET LOCAL session_replication_role = replica"); err != nil {
+		return domain.WipeReport{}, fmt.Errorf("failed to delete all tables: %w", err)
+	}
+
+	for _, step := range steps {
+		if !step.enabled {
+			continue
+		}
+
+		var targets []wipeTarget
+		if err = tx.SelectContext(ctx, &targets, step.query); err != nil {
+			return domain.WipeReport{}, fmt.Errorf("postgres: %w", err)
+		}
+
+		for _, target := range targets {
+			query := fmt.Sprintf("DROP %s IF EXISTS %s%s CASCADE",
+				target.Keyword, pgclient.QuoteQualified("public", target.Name), target.Args)
+			if _, err = tx.ExecContext(ctx, query); err != nil {
+				return domain.WipeReport{}, fmt.Errorf("failed to drop %s: %w", target.Name, err)
+			}
+			*step.removed = append(*step.removed, target.Name+target.Args)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.WipeReport{}, fmt.Errorf("commit: %w", err)
+	}
+
+	for _, object := range append(preview.Views, preview.Sequences...) {
+		if object.Schema == "public" && reported(report, object) {
+			continue
+		}
+		report.Cascaded = append(report.Cascaded, object)
+	}
+
+	return report, nil
+}
+
+func reported(report domain.WipeReport, object domain.WipeObject) bool {
+	switch object.Kind {
+	case "view":
+		return slices.Contains(report.Views, object.Name)
+	case "materialized view":
+		return slices.Contains(report.MaterializedViews, object.Name)
+	case "sequence":
+		return slices.Contains(report.Sequences, object.Name)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+)
+
+type ActivityRepository interface {
+	Activity(ctx context.Context, all bool) ([]domain.Session, error)
+	LockedSessions(ctx context.Context) ([]domain.Session, map[int]domain.LockWait, error)
+	CancelBackend(ctx context.Context, pid int) (bool, error)
+	TerminateBackend(ctx context.Context, pid int) (bool, error)
+}
+
+func (s *Service) Activity(ctx context.Context, all bool) ([]domain.Session, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	sessions, err := conn.repo.Activity(ctx, all)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return sessions, nil
+}
+
+// Locks builds the blocking tree: sessions that block others without waiting
+// themselves are the roots, every session is listed under each session blocking it.
+// Sessions waiting on each other in a cycle are listed under one of its members.
+func (s *Service) Locks(ctx context.Context) (domain.LockTree, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.LockTree{}, err
+	}
+	defer conn.release()
+	sessions, waits, err := conn.repo.LockedSessions(ctx)
+	if err != nil {
+		return domain.LockTree{}, fmt.Errorf("repo: %w", err)
+	}
+
+	byPID := make(map[int]domain.Session, len(sessions))
+	for _, session := range sessions {
+		byPID[session.PID] = session
+	}
+
+	tree := domain.LockTree{Roots: []domain.LockNode{}}
+	visited := make(map[int]bool, len(sessions))
+	for _, session := range sessions {
+		if len(session.BlockedBy) > 0 {
+			tree.Blocked++
+		}
+		root := !slices.ContainsFunc(session.BlockedBy, func(pid int) bool {
+			_, ok := byPID[pid]
+			return ok
+		})
+		if root {
+			tree.Roots = append(tree.Roots, lockNode(session, sessions, waits, nil, visited))
+		}
+	}
+
+	// A wait cycle has no root above it, one of its members becomes a root.
+	for _, session := range sessions {
+		if !visited[session.PID] {
+			root := cycleMember(session, byPID)
+			tree.Roots = append(tree.Roots, lockNode(root, sessions, waits, nil, visited))
+		}
+	}
+
+	return tree, nil
+}
+
+// cycleMember follows the blockers of session up to a session met twice, which
+// is part of the cycle session waits behind.
+func cycleMember(session domain.Session, byPID map[int]domain.Session) domain.Session {
+	seen := map[int]bool{}
+	for !seen[session.PID] {
+		seen[session.PID] = true
+		for _, pid := range session.BlockedBy {
+			if blocker, ok := byPID[pid]; ok {
+				session = blocker
+				break
+			}
+		}
+	}
+
+	return session
+}
+
+// lockNode expands the sessions blocked by session. path holds the pids above
+// it so that a deadlock cycle does not recurse forever, visited collects every
+// pid listed.
+func lockNode(session domain.Session, sessions []domain.Session, waits map[int]domain.LockWait, path []int, visited map[int]bool) domain.LockNode {
+	node := domain.LockNode{Session: session, Blocks: []domain.LockNode{}}
+	if wait, ok := waits[session.PID]; ok {
+		node.WaitingFor = &wait
+	}
+	visited[session.PID] = true
+
+	path = append(path, session.PID)
+	for _, other := range sessions {
+		if slices.Contains(other.BlockedBy, session.PID) && !slices.Contains(path, other.PID) {
+			node.Blocks = append(node.Blocks, lockNode(other, sessions, waits, path, visited))
+		}
+	}
+
+	return node
+}
+
+// CancelBackend cancels the running query of a session.
+func (s *Service) CancelBackend(ctx context.Context, pid int) (domain.SessionSignaled, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.SessionSignaled{}, err
+	}
+	defer conn.release()
+	ok, err := conn.repo.CancelBackend(ctx, pid)
+	if err != nil {
+		return domain.SessionSignaled{}, fmt.Errorf("repo: %w", err)
+	}
+	if !ok {
+		return domain.SessionSignaled{}, fmt.Errorf("%w: no backend with pid %d", domain.ErrNotFound, pid)
+	}
+	return domain.SessionSignaled{PID: pid, Message: "Query cancelled", Success: true}, nil
+}
+
+// TerminateBackend closes a session, rolling back its open transaction.
+func (s *Service) TerminateBackend(ctx context.Context, pid int) (domain.SessionSignaled, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.SessionSignaled{}, err
+	}
+	defer conn.release()
+	ok, err := conn.repo.TerminateBackend(ctx, pid)
+	if err != nil {
+		return domain.SessionSignaled{}, fmt.Errorf("repo: %w", err)
+	}
+	if !ok {
+		return domain.SessionSignaled{}, fmt.Errorf("%w: no backend with pid %d", domain.ErrNotFound, pid)
+	}
+	return domain.SessionSignaled{PID: pid, Message: "Session terminated", Success: true}, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const defaultConfirmTokenTTL = 2 * time.Minute
+
+// Destructive actions confirmed with a token issued by their preview.
+const (
+	confirmWipe         = "wipe"
+	confirmDropDatabase = "drop_database"
+)
+
+type confirmToken struct {
+	action     string
+	connection string
+	targets    []string
+	expiresAt  time.Time
+}
+
+// confirmTokens holds the confirmation tokens issued by the previews of destructive
+// actions. A token is single-use and bound to the action, the connection and the
+// objects it was issued for.
+type confirmTokens struct {
+	mu     sync.Mutex
+	tokens map[string]confirmToken
+}
+
+func (w *confirmTokens) issue(token confirmToken) string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now()
+	for key, t := range w.tokens {
+		if now.After(t.expiresAt) {
+			delete(w.tokens, key)
+		}
+	}
+
+	key := uuid.NewString()
+	w.tokens[key] = token
+
+	return key
+}
+
+// take consumes the token, which must have been issued for action on connection.
+func (w *confirmTokens) take(key, action, connection string) (confirmToken, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	token, ok := w.tokens[key]
+	delete(w.tokens, key)
+
+	if !ok || time.Now().After(token.expiresAt) || token.action != action || token.connection != connection {
+		return confirmToken{}, false
+	}
+
+	return token, true
+}
+
+// confirmExpiry returns when a token issued now expires.
+func (s *Service) confirmExpiry() time.Time {
+	ttl := s.cfg.WipeTokenTTL
+	if ttl <= 0 {
+		ttl = defaultConfirmTokenTTL
+	}
+
+	return time.Now().Add(ttl)
+}
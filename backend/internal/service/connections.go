@@ -0,0 +1,443 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"l6/internal/config"
+	"l6/internal/domain"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultPostgresPort = "5432"
+
+var connectionIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Connector opens a pool for a connection profile and returns the repository
+// working on it together with a function closing the pool.
+type Connector func(ctx context.Context, cfg *config.PostgresConfig) (Repository, func(ctx context.Context) error, error)
+
+// connection is a registered profile. users counts the requests and jobs working
+// on its pool; a replaced or removed profile is retired and closes its pool once
+// the last of them releases it.
+type connection struct {
+	mu       sync.Mutex
+	cfg      config.ConnectionConfig
+	repo     Repository
+	shutdown func(ctx context.Context) error
+	users    int
+	retired  bool
+}
+
+// Connections is the registry of database profiles. Pools are opened lazily on
+// first use and every profile keeps its own pool and backup directory.
+type Connections struct {
+	mu        sync.RWMutex
+	connect   Connector
+	cfg       *config.AppConfig
+	defaultID string
+	conns     map[string]*connection
+}
+
+func NewConnections(connect Connector, cfg *config.AppConfig, defaultID string) *Connections {
+	return &Connections{
+		connect:   connect,
+		cfg:       cfg,
+		defaultID: defaultID,
+		conns:     make(map[string]*connection),
+	}
+}
+
+// Register adds a profile without connecting to it.
+func (c *Connections) Register(profile config.ConnectionConfig) error {
+	if err := c.normalize(&profile); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.conns[profile.ID]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrConnectionExists, profile.ID)
+	}
+	c.conns[profile.ID] = &connection{cfg: profile}
+
+	return nil
+}
+
+// Connect opens the pool of the profile with the given ID if it is not open yet.
+// An empty ID selects the default profile.
+func (c *Connections) Connect(ctx context.Context, id string) error {
+	_, err := c.open(ctx, id)
+	return err
+}
+
+// Close closes the pools of every registered profile.
+func (c *Connections) Close(ctx context.Context) error {
+	c.mu.Lock()
+	conns := make([]*connection, 0, len(c.conns))
+	for _, conn := range c.conns {
+		conns = append(conns, conn)
+	}
+	c.mu.Unlock()
+
+	var errs []error
+	for _, conn := range conns {
+		if err := conn.close(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("connection %s: %w", conn.cfg.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// connected returns the profiles whose pool is open. The caller releases each of
+// them when done.
+func (c *Connections) connected() []*connection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	conns := make([]*connection, 0, len(c.conns))
+	for _, conn := range c.conns {
+		conn.mu.Lock()
+		if conn.repo != nil && conn.shutdown != nil {
+			conn.users++
+			conns = append(conns, conn)
+		}
+		conn.mu.Unlock()
+	}
+
+	return conns
+}
+
+// open returns the profile with its pool open. The caller releases it when done.
+func (c *Connections) open(ctx context.Context, id string) (*connection, error) {
+	for {
+		conn, err := c.get(id)
+		if err != nil {
+			return nil, err
+		}
+
+		conn.mu.Lock()
+		if conn.retired {
+			// Replaced or removed since the lookup, look it up again.
+			conn.mu.Unlock()
+			continue
+		}
+		if conn.repo == nil {
+			if err := c.dial(ctx, conn); err != nil {
+				conn.mu.Unlock()
+				return nil, err
+			}
+		}
+		conn.users++
+		conn.mu.Unlock()
+
+		return conn, nil
+	}
+}
+
+// replace swaps the profile registered under id for conn, unless it was removed
+// or replaced since old was looked up.
+func (c *Connections) replace(id string, old, conn *connection) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current, ok := c.conns[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", domain.ErrConnectionNotFound, id)
+	}
+	if current != old {
+		return fmt.Errorf("%w: connection %s was changed concurrently", domain.ErrConflict, id)
+	}
+	c.conns[id] = conn
+
+	return nil
+}
+
+// remove unregisters the profile with the given ID and returns it.
+func (c *Connections) remove(id string) (*connection, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, ok := c.conns[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrConnectionNotFound, id)
+	}
+	delete(c.conns, id)
+
+	return conn, nil
+}
+
+func (c *Connections) dial(ctx context.Context, conn *connection) error {
+	repo, shutdown, err := c.connect(ctx, &conn.cfg.Postgres)
+	if err != nil {
+		return fmt.Errorf("connection %s: %w", conn.cfg.ID, err)
+	}
+	conn.repo = repo
+	conn.shutdown = shutdown
+
+	return nil
+}
+
+func (c *Connections) get(id string) (*connection, error) {
+	if id == "" {
+		id = c.defaultID
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	conn, ok := c.conns[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrConnectionNotFound, id)
+	}
+
+	return conn, nil
+}
+
+// usingDatabase returns the ids of the profiles connecting to the database.
+func (c *Connections) usingDatabase(database string) []string {
+	c.mu.RLock()
+	conns := make([]*connection, 0, len(c.conns))
+	for _, conn := range c.conns {
+		conns = append(conns, conn)
+	}
+	c.mu.RUnlock()
+
+	var ids []string
+	for _, conn := range conns {
+		conn.mu.Lock()
+		if conn.cfg.Postgres.Database == database {
+			ids = append(ids, conn.cfg.ID)
+		}
+		conn.mu.Unlock()
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func (c *Connections) normalize(profile *config.ConnectionConfig) error {
+	if !connectionIDPattern.MatchString(profile.ID) {
+		return fmt.Errorf("%w: connection id %q may only contain letters, digits, '-' and '_'", domain.ErrInvalidRequest, profile.ID)
+	}
+	if profile.Postgres.Host == "" || profile.Postgres.Database == "" {
+		return fmt.Errorf("%w: host and database are required", domain.ErrInvalidRequest)
+	}
+	if profile.Name == "" {
+		profile.Name = profile.ID
+	}
+	if profile.Postgres.Port == "" {
+		profile.Postgres.Port = defaultPostgresPort
+	}
+	if profile.Postgres.ConnectionAttempts < 1 {
+		profile.Postgres.ConnectionAttempts = 1
+	}
+	if profile.BackupDir == "" {
+		profile.BackupDir = filepath.Join(c.cfg.BackupDir, profile.ID)
+	}
+
+	return nil
+}
+
+func (c *connection) close(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closePool(ctx)
+}
+
+func (c *connection) closePool(ctx context.Context) error {
+	if c.shutdown == nil {
+		return nil
+	}
+
+	err := c.shutdown(ctx)
+	c.shutdown = nil
+
+	return err
+}
+
+// acquire adds a user to a connection the caller already holds.
+func (c *connection) acquire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.users++
+}
+
+// release gives back a connection returned by open or connected. The last user
+// of a retired connection closes its pool.
+func (c *connection) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.users--
+	if c.retired && c.users == 0 {
+		_ = c.closePool(context.Background())
+	}
+}
+
+// retire marks an unregistered connection for closing. The pool is closed now if
+// nothing uses it, otherwise by the last release.
+func (c *connection) retire(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.retired = true
+	if c.users > 0 {
+		return nil
+	}
+
+	return c.closePool(ctx)
+}
+
+func (c *connection) info(defaultID string) domain.Connection {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return domain.Connection{
+		ID:        c.cfg.ID,
+		Name:      c.cfg.Name,
+		Host:      c.cfg.Postgres.Host,
+		Port:      c.cfg.Postgres.Port,
+		Database:  c.cfg.Postgres.Database,
+		Username:  c.cfg.Postgres.Username,
+		BackupDir: c.cfg.BackupDir,
+		Default:   c.cfg.ID == defaultID,
+		Connected: c.repo != nil,
+	}
+}
+
+func (s *Service) ListConnections(ctx context.Context) ([]domain.Connection, error) {
+	s.connections.mu.RLock()
+	conns := make([]*connection, 0, len(s.connections.conns))
+	for _, conn := range s.connections.conns {
+		conns = append(conns, conn)
+	}
+	s.connections.mu.RUnlock()
+
+	result := make([]domain.Connection, 0, len(conns))
+	for _, conn := range conns {
+		result = append(result, conn.info(s.connections.defaultID))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result, nil
+}
+
+func (s *Service) Connection(ctx context.Context, id string) (domain.Connection, error) {
+	conn, err := s.connections.get(id)
+	if err != nil {
+		return domain.Connection{}, err
+	}
+	return conn.info(s.connections.defaultID), nil
+}
+
+// AddConnection registers a new profile after checking that it is reachable.
+func (s *Service) AddConnection(ctx context.Context, profile config.ConnectionConfig) (domain.Connection, error) {
+	if err := s.connections.normalize(&profile); err != nil {
+		return domain.Connection{}, err
+	}
+	if _, err := s.connections.get(profile.ID); err == nil {
+		return domain.Connection{}, fmt.Errorf("%w: %s", domain.ErrConnectionExists, profile.ID)
+	}
+
+	conn := &connection{cfg: profile}
+	if err := s.connections.dial(ctx, conn); err != nil {
+		return domain.Connection{}, err
+	}
+
+	s.connections.mu.Lock()
+	if _, ok := s.connections.conns[profile.ID]; ok {
+		s.connections.mu.Unlock()
+		_ = conn.close(ctx)
+
+		return domain.Connection{}, fmt.Errorf("%w: %s", domain.ErrConnectionExists, profile.ID)
+	}
+	s.connections.conns[profile.ID] = conn
+	s.connections.mu.Unlock()
+
+	return conn.info(s.connections.defaultID), nil
+}
+
+// UpdateConnection replaces a profile and reconnects it. An empty password keeps the
+// old one. The old pool is closed once the requests and jobs using it are done.
+func (s *Service) UpdateConnection(ctx context.Context, id string, profile config.ConnectionConfig) (domain.Connection, error) {
+	old, err := s.connections.get(id)
+	if err != nil {
+		return domain.Connection{}, err
+	}
+
+	profile.ID = old.cfg.ID
+	if profile.Postgres.Password == "" {
+		profile.Postgres.Password = old.cfg.Postgres.Password
+	}
+	if err := s.connections.normalize(&profile); err != nil {
+		return domain.Connection{}, err
+	}
+
+	conn := &connection{cfg: profile}
+	if err := s.connections.dial(ctx, conn); err != nil {
+		return domain.Connection{}, err
+	}
+
+	if err := s.connections.replace(profile.ID, old, conn); err != nil {
+		_ = conn.close(ctx)
+		return domain.Connection{}, err
+	}
+
+	if err := old.retire(ctx); err != nil {
+		return domain.Connection{}, fmt.Errorf("close previous pool: %w", err)
+	}
+
+	return conn.info(s.connections.defaultID), nil
+}
+
+// RemoveConnection unregisters a profile. Its pool is closed once the requests and
+// jobs using it are done.
+func (s *Service) RemoveConnection(ctx context.Context, id string) error {
+	if id == "" || id == s.connections.defaultID {
+		return fmt.Errorf("%w: the default connection cannot be removed", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.connections.remove(id)
+	if err != nil {
+		return err
+	}
+
+	if err := conn.retire(ctx); err != nil {
+		return fmt.Errorf("close pool: %w", err)
+	}
+	return nil
+}
+
+// TestConnection connects to the profile if needed and pings it.
+func (s *Service) TestConnection(ctx context.Context, id string) (domain.ConnectionTested, error) {
+	start := time.Now()
+
+	conn, err := s.connections.open(ctx, id)
+	if err == nil {
+		defer conn.release()
+		err = conn.repo.Ping(ctx)
+	}
+	if errors.Is(err, domain.ErrConnectionNotFound) {
+		return domain.ConnectionTested{}, err
+	}
+	if err != nil {
+		return domain.ConnectionTested{Message: err.Error(), Success: false}, nil
+	}
+
+	return domain.ConnectionTested{
+		Message:   "Connection is alive",
+		Success:   true,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}, nil
+}
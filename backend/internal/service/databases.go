@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+	"strings"
+)
+
+type DatabaseRepository interface {
+	Databases(ctx context.Context) ([]domain.Database, error)
+	CreateDatabase(ctx context.Context, database domain.DatabaseCreate) error
+	RenameDatabase(ctx context.Context, name string, newName string) error
+	DropDatabase(ctx context.Context, name string, force bool) error
+}
+
+func (s *Service) Databases(ctx context.Context) ([]domain.Database, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	databases, err := conn.repo.Databases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return databases, nil
+}
+
+func (s *Service) CreateDatabase(ctx context.Context, database domain.DatabaseCreate) error {
+	if database.Name == "" {
+		return fmt.Errorf("%w: database name is required", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	err = conn.repo.CreateDatabase(ctx, database)
+	if err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) RenameDatabase(ctx context.Context, name string, newName string) error {
+	if newName == "" {
+		return fmt.Errorf("%w: new database name is required", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = s.checkUnused(name); err != nil {
+		return err
+	}
+	err = conn.repo.RenameDatabase(ctx, name, newName)
+	if err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+// PreviewDropDatabase reports the database DropDatabase would remove and issues a
+// short-lived token that has to be passed to it.
+func (s *Service) PreviewDropDatabase(ctx context.Context, name string) (domain.DatabaseDropPreview, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.DatabaseDropPreview{}, err
+	}
+	defer conn.release()
+	if err = s.checkUnused(name); err != nil {
+		return domain.DatabaseDropPreview{}, err
+	}
+
+	databases, err := conn.repo.Databases(ctx)
+	if err != nil {
+		return domain.DatabaseDropPreview{}, fmt.Errorf("repo: %w", err)
+	}
+	i := slices.IndexFunc(databases, func(d domain.Database) bool { return d.Name == name })
+	if i < 0 {
+		return domain.DatabaseDropPreview{}, fmt.Errorf("%w: database %s", domain.ErrNotFound, name)
+	}
+
+	preview := domain.DatabaseDropPreview{Database: databases[i], ExpiresAt: s.confirmExpiry()}
+	preview.Token = s.confirms.issue(confirmToken{
+		action:     confirmDropDatabase,
+		connection: conn.cfg.ID,
+		targets:    []string{name},
+		expiresAt:  preview.ExpiresAt,
+	})
+
+	return preview, nil
+}
+
+// DropDatabase drops the database with the token issued by PreviewDropDatabase.
+// force terminates the sessions connected to it first.
+func (s *Service) DropDatabase(ctx context.Context, name string, token string, force bool) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+
+	issued, ok := s.confirms.take(token, confirmDropDatabase, conn.cfg.ID)
+	if !ok || !slices.Equal(issued.targets, []string{name}) {
+		return fmt.Errorf("%w: confirmation token is invalid or expired, request a new preview", domain.ErrInvalidRequest)
+	}
+	if err = s.checkUnused(name); err != nil {
+		return err
+	}
+
+	err = conn.repo.DropDatabase(ctx, name, force)
+	if err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+// checkUnused refuses to drop or rename a database a registered connection profile
+// points at. The profile would lose its database, and a forced drop would terminate
+// its sessions.
+func (s *Service) checkUnused(name string) error {
+	if ids := s.connections.usingDatabase(name); len(ids) > 0 {
+		return fmt.Errorf("%w: database %s is used by connection %s", domain.ErrInvalidRequest, name, strings.Join(ids, ", "))
+	}
+	return nil
+}
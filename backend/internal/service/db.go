@@ -2,33 +2,83 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"l6/internal/config"
 	"l6/internal/domain"
+	"l6/pkg/pgclient"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 )
 
 type Repository interface {
+	DatabaseRepository
+	WipeRepository
+	SchemaRepository
+	MigrationRepository
+	DDLRepository
+	IndexRepository
+	ObjectRepository
+	SequenceRepository
+	TypeRepository
+	RoleRepository
+	RLSRepository
+	ActivityRepository
+	HealthRepository
+	StatementsRepository
+	TableStatsRepository
+	SettingsRepository
+	ExtensionRepository
+	ReplicationRepository
+	LogicalReplicationRepository
+	Ping(ctx context.Context) error
 	Tables(ctx context.Context) ([]string, error)
 	ExecuteQuery(ctx context.Context, query string) (string, error)
-	CreateBackup(ctx context.Context, dir string) (domain.BackupCreated, error)
-	RestoreBackup(ctx context.Context, filename string, dir string) error
-	DeleteAllTables(ctx context.Context) error
+	CreateBackup(ctx context.Context, dir string, opts domain.BackupOptions, progress *domain.ToolProgress) (domain.BackupCreated, error)
+	RestoreBackup(ctx context.Context, filename string, dir string, opts domain.RestoreOptions, progress *domain.ToolProgress) error
+	BackupContents(ctx context.Context, filename string, dir string) ([]domain.ArchiveEntry, error)
 }
 
 type Service struct {
-	repo Repository
-	cfg  *config.AppConfig
+	connections *Connections
+	cfg         *config.AppConfig
+	confirms    confirmTokens
+	health      healthSamples
+	statements  statementSnapshots
+	jobs        jobQueue
 }
 
-func NewDBService(repo Repository, cfg *config.AppConfig) *Service {
-	return &Service{repo: repo, cfg: cfg}
+func NewDBService(connections *Connections, cfg *config.AppConfig) *Service {
+	queueSize := cfg.Jobs.Queue
+	if queueSize <= 0 {
+		queueSize = defaultJobQueue
+	}
+
+	return &Service{
+		connections: connections,
+		cfg:         cfg,
+		confirms:    confirmTokens{tokens: make(map[string]confirmToken)},
+		health:      healthSamples{samples: make(map[string]domain.HealthStats)},
+		statements:  statementSnapshots{snapshots: make(map[string][]statementsSnapshot)},
+		jobs:        jobQueue{jobs: make(map[string]*job), queue: make(chan *job, queueSize)},
+	}
+}
+
+// conn returns the connection profile selected for the request.
+func (s *Service) conn(ctx context.Context) (*connection, error) {
+	return s.connections.open(ctx, domain.ConnectionFromContext(ctx))
 }
 
 func (s *Service) Tables(ctx context.Context) ([]string, error) {
-	tables, err := s.repo.Tables(ctx)
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	tables, err := conn.repo.Tables(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("repo: %w", err)
 	}
@@ -36,7 +86,12 @@ func (s *Service) Tables(ctx context.Context) ([]string, error) {
 }
 
 func (s *Service) ExecuteQuery(ctx context.Context, query string) (string, error) {
-	result, err := s.repo.ExecuteQuery(ctx, query)
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.release()
+	result, err := conn.repo.ExecuteQuery(ctx, query)
 	if err != nil {
 		return "", fmt.Errorf("repo: %w", err)
 	}
@@ -44,8 +99,16 @@ func (s *Service) ExecuteQuery(ctx context.Context, query string) (string, error
 }
 
 func (s *Service) ListBackups(ctx context.Context) ([]domain.Backup, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
 
-	dir, err := os.Open(s.cfg.BackupDir)
+	dir, err := os.Open(conn.cfg.BackupDir)
+	if os.IsNotExist(err) {
+		return []domain.Backup{}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("не удалось открыть директорию: %w", err)
 	}
@@ -59,25 +122,69 @@ func (s *Service) ListBackups(ctx context.Context) ([]domain.Backup, error) {
 	var backups []domain.Backup
 
 	for _, fileInfo := range fileInfos {
-		if !fileInfo.IsDir() {
-			backup := domain.Backup{
-				Filename:  fileInfo.Name(),
-				CreatedAt: fileInfo.ModTime(),
-				Size:      fileInfo.Size(),
-			}
-			backups = append(backups, backup)
+		if strings.HasSuffix(fileInfo.Name(), domain.BackupMetaSuffix) {
+			continue
+		}
+		fullPath := filepath.Join(conn.cfg.BackupDir, fileInfo.Name())
+		format, err := pgclient.DetectDumpFormat(fullPath)
+		if err != nil {
+			// Unreadable files and directories other than directory format dumps are skipped.
+			continue
+		}
+		size := fileInfo.Size()
+		if fileInfo.IsDir() {
+			size = dirSize(fullPath)
 		}
+		backup := domain.Backup{
+			Filename:  fileInfo.Name(),
+			Format:    format,
+			CreatedAt: fileInfo.ModTime(),
+			Size:      size,
+			Options:   backupOptions(fullPath),
+		}
+		backups = append(backups, backup)
 	}
 
 	return backups, nil
 }
 
-func (s *Service) CreateBackup(ctx context.Context) (domain.BackupCreated, error) {
-	backup, err := s.repo.CreateBackup(ctx, s.cfg.BackupDir)
+// CreateBackup queues a dump of the database in the requested format, plain SQL by
+// default. The dump runs in the background, see RunJobs.
+func (s *Service) CreateBackup(ctx context.Context, opts domain.BackupOptions) (domain.Job, error) {
+	switch opts.Format {
+	case "":
+		opts.Format = domain.BackupPlain
+	case domain.BackupPlain, domain.BackupCustom, domain.BackupDirectory, domain.BackupTar:
+	default:
+		return domain.Job{}, fmt.Errorf("%w: format must be plain, custom, directory or tar", domain.ErrInvalidRequest)
+	}
+	if opts.Jobs < 0 || opts.Jobs > 1 && opts.Format != domain.BackupDirectory {
+		return domain.Job{}, fmt.Errorf("%w: parallel jobs are only supported by the directory format", domain.ErrInvalidRequest)
+	}
+	if opts.SchemaOnly && opts.DataOnly {
+		return domain.Job{}, fmt.Errorf("%w: schema_only and data_only are mutually exclusive", domain.ErrInvalidRequest)
+	}
+	if opts.Clean && opts.DataOnly {
+		return domain.Job{}, fmt.Errorf("%w: clean cannot be combined with data_only", domain.ErrInvalidRequest)
+	}
+	for _, pattern := range slices.Concat(opts.Tables, opts.ExcludeTables, opts.Schemas, opts.ExcludeSchemas) {
+		if strings.TrimSpace(pattern) == "" {
+			return domain.Job{}, fmt.Errorf("%w: table and schema patterns cannot be empty", domain.ErrInvalidRequest)
+		}
+	}
+
+	conn, err := s.conn(ctx)
 	if err != nil {
-		return domain.BackupCreated{}, fmt.Errorf("repo: %w", err)
+		return domain.Job{}, err
 	}
-	return backup, nil
+	defer conn.release()
+	return s.startJob(conn, domain.Job{Kind: domain.JobBackup}, func(ctx context.Context, progress *domain.ToolProgress) (string, any, error) {
+		backup, err := conn.repo.CreateBackup(ctx, conn.cfg.BackupDir, opts, progress)
+		if err != nil {
+			return "", nil, fmt.Errorf("repo: %w", err)
+		}
+		return backup.Filename, nil, nil
+	})
 }
 
 func (s *Service) DownloadBackup(ctx context.Context, filename string) ([]byte, error) {
@@ -85,8 +192,18 @@ func (s *Service) DownloadBackup(ctx context.Context, filename string) ([]byte,
 		return nil, errors.New("filename is required")
 	}
 
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+
 	cleanFilename := filepath.Base(filename)
-	fullPath := filepath.Join(s.cfg.BackupDir, cleanFilename)
+	fullPath := filepath.Join(conn.cfg.BackupDir, cleanFilename)
+
+	if info, err := os.Stat(fullPath); err == nil && info.IsDir() {
+		return nil, fmt.Errorf("%w: directory format backups cannot be downloaded", domain.ErrInvalidRequest)
+	}
 
 	data, err := os.ReadFile(fullPath)
 	if err != nil {
@@ -97,26 +214,176 @@ func (s *Service) DownloadBackup(ctx context.Context, filename string) ([]byte,
 }
 
 func (s *Service) DeleteBackup(ctx context.Context, filename string) error {
-	fullPath := filepath.Join(s.cfg.BackupDir, filename)
-	err := os.Remove(fullPath)
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+
+	fullPath := filepath.Join(conn.cfg.BackupDir, filepath.Base(filename))
+	if info, statErr := os.Stat(fullPath); statErr == nil && info.IsDir() {
+		err = os.RemoveAll(fullPath)
+	} else {
+		err = os.Remove(fullPath)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to delete backup file: %w", err)
 	}
+	if err = os.Remove(fullPath + domain.BackupMetaSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete backup metadata: %w", err)
+	}
 	return nil
 }
 
-func (s *Service) RestoreBackup(ctx context.Context, filename string) error {
-	err := s.repo.RestoreBackup(ctx, filename, s.cfg.BackupDir)
+// RestoreBackup queues the restore of a backup, optionally only some schemas,
+// tables and entry types of an archive backup, into another database or schema.
+func (s *Service) RestoreBackup(ctx context.Context, filename string, opts domain.RestoreOptions) (domain.Job, error) {
+	conn, err := s.conn(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to restore backup file: %w", err)
+		return domain.Job{}, err
 	}
-	return nil
+	defer conn.release()
+
+	format, err := backupFormat(conn.cfg.BackupDir, filename)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	if err = validateRestore(format, &opts); err != nil {
+		return domain.Job{}, err
+	}
+	if opts.TargetDatabase != "" {
+		databases, err := conn.repo.Databases(ctx)
+		if err != nil {
+			return domain.Job{}, fmt.Errorf("repo: %w", err)
+		}
+		if !slices.ContainsFunc(databases, func(d domain.Database) bool { return d.Name == opts.TargetDatabase }) {
+			return domain.Job{}, fmt.Errorf("%w: target database %s", domain.ErrNotFound, opts.TargetDatabase)
+		}
+	}
+
+	filename = filepath.Base(filename)
+	return s.startJob(conn, domain.Job{Kind: domain.JobRestore, Filename: filename}, func(ctx context.Context, progress *domain.ToolProgress) (string, any, error) {
+		err := conn.repo.RestoreBackup(ctx, filename, conn.cfg.BackupDir, opts, progress)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to restore backup file: %w", err)
+		}
+		return filename, nil, nil
+	})
 }
 
-func (s *Service) DeleteAllTables(ctx context.Context) error {
-	err := s.repo.DeleteAllTables(ctx)
+// BackupContents lists the entries of an archive backup.
+func (s *Service) BackupContents(ctx context.Context, filename string) (domain.BackupContents, error) {
+	conn, err := s.conn(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to delete all tables: %w", err)
+		return domain.BackupContents{}, err
 	}
+	defer conn.release()
+
+	format, err := backupFormat(conn.cfg.BackupDir, filename)
+	if err != nil {
+		return domain.BackupContents{}, err
+	}
+	if format == domain.BackupPlain {
+		return domain.BackupContents{}, fmt.Errorf("%w: plain SQL backups have no table of contents", domain.ErrInvalidRequest)
+	}
+
+	entries, err := conn.repo.BackupContents(ctx, filename, conn.cfg.BackupDir)
+	if err != nil {
+		return domain.BackupContents{}, fmt.Errorf("repo: %w", err)
+	}
+	if entries == nil {
+		entries = []domain.ArchiveEntry{}
+	}
+
+	return domain.BackupContents{Filename: filepath.Base(filename), Format: format, Entries: entries}, nil
+}
+
+func backupFormat(dir, filename string) (string, error) {
+	if filename == "" {
+		return "", fmt.Errorf("%w: filename is required", domain.ErrInvalidRequest)
+	}
+	fullPath := filepath.Join(dir, filepath.Base(filename))
+	if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+		return "", fmt.Errorf("%w: backup %s", domain.ErrNotFound, filepath.Base(filename))
+	}
+	format, err := pgclient.DetectDumpFormat(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("detect backup format: %w", err)
+	}
+	return format, nil
+}
+
+// validateRestore checks the restore options against the backup format and
+// normalizes the entry types to upper case.
+func validateRestore(format string, opts *domain.RestoreOptions) error {
+	if format == domain.BackupPlain {
+		if len(opts.Schemas) > 0 || len(opts.Tables) > 0 || len(opts.Types) > 0 ||
+			opts.TargetSchema != "" || opts.Clean || opts.Jobs > 1 {
+			return fmt.Errorf("%w: selective restore needs a custom, directory or tar backup", domain.ErrInvalidRequest)
+		}
+		return nil
+	}
+
+	for i, typ := range opts.Types {
+		opts.Types[i] = strings.ToUpper(strings.TrimSpace(typ))
+		if opts.Types[i] == "" {
+			return fmt.Errorf("%w: entry types cannot be empty", domain.ErrInvalidRequest)
+		}
+	}
+	for _, name := range slices.Concat(opts.Schemas, opts.Tables) {
+		if strings.TrimSpace(name) == "" {
+			return fmt.Errorf("%w: schema and table names cannot be empty", domain.ErrInvalidRequest)
+		}
+	}
+
+	switch {
+	case opts.Jobs < 0:
+		return fmt.Errorf("%w: jobs cannot be negative", domain.ErrInvalidRequest)
+	case opts.Jobs > 1 && format == domain.BackupTar:
+		return fmt.Errorf("%w: parallel restore needs a custom or directory backup", domain.ErrInvalidRequest)
+	case opts.Jobs > 1 && (opts.SingleTransaction || opts.TargetSchema != ""):
+		return fmt.Errorf("%w: parallel restore cannot run in a single transaction", domain.ErrInvalidRequest)
+	}
+
+	if opts.TargetSchema != "" {
+		if len(opts.Schemas) != 1 {
+			return fmt.Errorf("%w: restoring into a target schema needs exactly one source schema", domain.ErrInvalidRequest)
+		}
+		if opts.TargetSchema == opts.Schemas[0] {
+			return fmt.Errorf("%w: target schema must differ from the source schema", domain.ErrInvalidRequest)
+		}
+		if opts.Clean {
+			return fmt.Errorf("%w: clean cannot be combined with a target schema", domain.ErrInvalidRequest)
+		}
+	}
+
 	return nil
 }
+
+// dirSize sums the sizes of the files of a directory format backup.
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil //nolint:nilerr // unreadable entries do not count
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// backupOptions reads the options of a backup from its sidecar metadata file.
+func backupOptions(path string) *domain.BackupOptions {
+	data, err := os.ReadFile(path + domain.BackupMetaSuffix)
+	if err != nil {
+		return nil
+	}
+	var meta domain.BackupMeta
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return nil
+	}
+	return &meta.Options
+}
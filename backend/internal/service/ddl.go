@@ -0,0 +1,376 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"regexp"
+	"strings"
+)
+
+type DDLRepository interface {
+	ExecDDL(ctx context.Context, statements []string) error
+}
+
+const defaultSchema = "public"
+
+// typeIdent is a plain or double-quoted identifier.
+const typeIdent = `(?:[a-z_][a-z0-9_$]*|"(?:[^"]|"")+")`
+
+// columnTypePattern accepts type names such as integer, character varying(20),
+// numeric(10, 2), timestamp(3) with time zone, interval day to second, public.mood,
+// "My Type" or text[]. Multi-word names are limited to the SQL standard types, so
+// that constraint and default clauses are rejected before they reach the
+// generated DDL.
+var columnTypePattern = regexp.MustCompile(`(?i)^(?:` +
+	`(?:time|timestamp)(?:\s*\(\s*\d+\s*\))?(?:\s+with(?:out)?\s+time\s+zone)?` +
+	`|interval(?:\s+(?:year|month|day|hour|minute|second)(?:\s+to\s+(?:month|hour|minute|second))?)?(?:\s*\(\s*\d+\s*\))?` +
+	`|(?:double\s+precision|(?:national\s+)?(?:character|char)(?:\s+varying)?|bit\s+varying)(?:\s*\(\s*\d+\s*\))?` +
+	`|` + typeIdent + `(?:\.` + typeIdent + `)?(?:\s*\(\s*\d+\s*(?:,\s*-?\d+\s*)?\))?` +
+	`)(?:\s*\[\s*\d*\s*\])*$`)
+
+var identityKinds = map[string]string{
+	"always":     "ALWAYS",
+	"by_default": "BY DEFAULT",
+}
+
+var constraintKinds = map[string]string{
+	"primary_key": "PRIMARY KEY",
+	"unique":      "UNIQUE",
+	"check":       "CHECK",
+	"foreign_key": "FOREIGN KEY",
+}
+
+var referentialActions = map[string]string{
+	"no_action":   "NO ACTION",
+	"restrict":    "RESTRICT",
+	"cascade":     "CASCADE",
+	"set_null":    "SET NULL",
+	"set_default": "SET DEFAULT",
+}
+
+// CreateTable generates the CREATE TABLE statement for the definition and applies
+// it unless preview is set.
+func (s *Service) CreateTable(ctx context.Context, table domain.TableDefinition, preview bool) (domain.DDLResult, error) {
+	statements, err := createTableDDL(table)
+	if err != nil {
+		return domain.DDLResult{}, err
+	}
+
+	return s.applyDDL(ctx, statements, preview)
+}
+
+// AlterTable generates the ALTER TABLE statements for the changes and applies them
+// in one transaction unless preview is set.
+func (s *Service) AlterTable(ctx context.Context, table string, alteration domain.TableAlteration, preview bool) (domain.DDLResult, error) {
+	statements, err := alterTableDDL(table, alteration)
+	if err != nil {
+		return domain.DDLResult{}, err
+	}
+	if len(statements) == 0 {
+		return domain.DDLResult{}, fmt.Errorf("%w: no changes requested", domain.ErrInvalidRequest)
+	}
+
+	return s.applyDDL(ctx, statements, preview)
+}
+
+func (s *Service) applyDDL(ctx context.Context, statements []string, preview bool) (domain.DDLResult, error) {
+	result := domain.DDLResult{
+		Statements: statements,
+		SQL:        strings.Join(statements, ";\n") + ";\n",
+	}
+	if preview {
+		return result, nil
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.DDLResult{}, err
+	}
+	defer conn.release()
+	if err = conn.repo.ExecDDL(ctx, statements); err != nil {
+		return domain.DDLResult{}, fmt.Errorf("repo: %w", err)
+	}
+	result.Applied = true
+
+	return result, nil
+}
+
+func createTableDDL(table domain.TableDefinition) ([]string, error) {
+	if table.Name == "" {
+		return nil, fmt.Errorf("%w: table name is required", domain.ErrInvalidRequest)
+	}
+	if len(table.Columns) == 0 {
+		return nil, fmt.Errorf("%w: table %s has no columns", domain.ErrInvalidRequest, table.Name)
+	}
+	name := pgclient.QuoteQualified(schemaOrDefault(table.Schema), table.Name)
+
+	definitions := make([]string, 0, len(table.Columns)+len(table.Constraints))
+	for _, column := range table.Columns {
+		definition, err := columnDDL(column)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+	for _, constraint := range table.Constraints {
+		definition, err := constraintDDL(constraint)
+		if err != nil {
+			return nil, err
+		}
+		definitions = append(definitions, definition)
+	}
+
+	statements := []string{fmt.Sprintf("CREATE TABLE %s (\n    %s\n)", name, strings.Join(definitions, ",\n    "))}
+	if table.Comment != nil {
+		statements = append(statements, commentDDL("TABLE "+name, *table.Comment))
+	}
+	for _, column := range table.Columns {
+		if column.Comment != nil {
+			statements = append(statements, commentDDL("COLUMN "+name+"."+pgclient.QuoteIdent(column.Name), *column.Comment))
+		}
+	}
+
+	return statements, nil
+}
+
+func alterTableDDL(table string, alteration domain.TableAlteration) ([]string, error) {
+	if table == "" {
+		return nil, fmt.Errorf("%w: table name is required", domain.ErrInvalidRequest)
+	}
+	name := pgclient.QuoteQualified(schemaOrDefault(alteration.Schema), table)
+	cascade := ""
+	if alteration.Cascade {
+		cascade = " CASCADE"
+	}
+
+	var statements []string
+	alter := func(format string, args ...any) {
+		statements = append(statements, "ALTER TABLE "+name+" "+fmt.Sprintf(format, args...))
+	}
+
+	for _, constraint := range alteration.DropConstraints {
+		alter("DROP CONSTRAINT %s%s", pgclient.QuoteIdent(constraint), cascade)
+	}
+	for _, column := range alteration.DropColumns {
+		alter("DROP COLUMN %s%s", pgclient.QuoteIdent(column), cascade)
+	}
+	for _, rename := range alteration.RenameColumns {
+		if rename.From == "" || rename.To == "" {
+			return nil, fmt.Errorf("%w: column rename needs both from and to", domain.ErrInvalidRequest)
+		}
+		alter("RENAME COLUMN %s TO %s", pgclient.QuoteIdent(rename.From), pgclient.QuoteIdent(rename.To))
+	}
+	for _, column := range alteration.AddColumns {
+		definition, err := columnDDL(column)
+		if err != nil {
+			return nil, err
+		}
+		alter("ADD COLUMN %s", definition)
+	}
+	for _, column := range alteration.AlterColumns {
+		columnStatements, err := alterColumnDDL(name, column)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, columnStatements...)
+	}
+	for _, constraint := range alteration.AddConstraints {
+		definition, err := constraintDDL(constraint)
+		if err != nil {
+			return nil, err
+		}
+		alter("ADD %s", definition)
+	}
+
+	for _, column := range alteration.AddColumns {
+		if column.Comment != nil {
+			statements = append(statements, commentDDL("COLUMN "+name+"."+pgclient.QuoteIdent(column.Name), *column.Comment))
+		}
+	}
+	if alteration.Comment != nil {
+		statements = append(statements, commentDDL("TABLE "+name, *alteration.Comment))
+	}
+	if alteration.RenameTo != "" {
+		alter("RENAME TO %s", pgclient.QuoteIdent(alteration.RenameTo))
+	}
+
+	return statements, nil
+}
+
+func alterColumnDDL(table string, column domain.ColumnAlteration) ([]string, error) {
+	if column.Name == "" {
+		return nil, fmt.Errorf("%w: column name is required", domain.ErrInvalidRequest)
+	}
+	name := pgclient.QuoteIdent(column.Name)
+	prefix := "ALTER TABLE " + table + " ALTER COLUMN " + name
+
+	var statements []string
+	if column.Type != "" {
+		if err := validateType(column.Name, column.Type); err != nil {
+			return nil, err
+		}
+		if err := validateExpression("column "+column.Name+": USING", column.Using); err != nil {
+			return nil, err
+		}
+		using := column.Using
+		if using == "" {
+			using = name + "::" + column.Type
+		}
+		statements = append(statements, fmt.Sprintf("%s TYPE %s USING %s", prefix, column.Type, using))
+	}
+
+	switch {
+	case column.DropDefault && column.Default != nil:
+		return nil, fmt.Errorf("%w: column %s: default and drop_default are mutually exclusive", domain.ErrInvalidRequest, column.Name)
+	case column.DropDefault:
+		statements = append(statements, prefix+" DROP DEFAULT")
+	case column.Default != nil:
+		if err := validateExpression("column "+column.Name+": default", *column.Default); err != nil {
+			return nil, err
+		}
+		statements = append(statements, prefix+" SET DEFAULT "+*column.Default)
+	}
+
+	if column.NotNull != nil {
+		if *column.NotNull {
+			statements = append(statements, prefix+" SET NOT NULL")
+		} else {
+			statements = append(statements, prefix+" DROP NOT NULL")
+		}
+	}
+
+	if column.Comment != nil {
+		statements = append(statements, commentDDL("COLUMN "+table+"."+name, *column.Comment))
+	}
+
+	return statements, nil
+}
+
+func columnDDL(column domain.ColumnDefinition) (string, error) {
+	if column.Name == "" {
+		return "", fmt.Errorf("%w: column name is required", domain.ErrInvalidRequest)
+	}
+	if err := validateType(column.Name, column.Type); err != nil {
+		return "", err
+	}
+	definition := pgclient.QuoteIdent(column.Name) + " " + column.Type
+
+	if column.Identity != "" {
+		kind, ok := identityKinds[column.Identity]
+		if !ok {
+			return "", fmt.Errorf("%w: column %s: identity must be always or by_default", domain.ErrInvalidRequest, column.Name)
+		}
+		if column.Default != nil {
+			return "", fmt.Errorf("%w: column %s: an identity column cannot have a default", domain.ErrInvalidRequest, column.Name)
+		}
+		definition += " GENERATED " + kind + " AS IDENTITY"
+	}
+	if column.Default != nil {
+		if err := validateExpression("column "+column.Name+": default", *column.Default); err != nil {
+			return "", err
+		}
+		definition += " DEFAULT " + *column.Default
+	}
+	if column.NotNull {
+		definition += " NOT NULL"
+	}
+
+	return definition, nil
+}
+
+func constraintDDL(constraint domain.ConstraintDefinition) (string, error) {
+	kind, ok := constraintKinds[constraint.Type]
+	if !ok {
+		return "", fmt.Errorf("%w: unknown constraint type %q", domain.ErrInvalidRequest, constraint.Type)
+	}
+
+	definition := ""
+	if constraint.Name != "" {
+		definition = "CONSTRAINT " + pgclient.QuoteIdent(constraint.Name) + " "
+	}
+
+	if constraint.Type == "check" {
+		if constraint.Check == "" {
+			return "", fmt.Errorf("%w: check constraint needs an expression", domain.ErrInvalidRequest)
+		}
+		if err := validateExpression("CHECK", constraint.Check); err != nil {
+			return "", err
+		}
+		return definition + kind + " (" + constraint.Check + ")", nil
+	}
+
+	if len(constraint.Columns) == 0 {
+		return "", fmt.Errorf("%w: %s constraint needs columns", domain.ErrInvalidRequest, constraint.Type)
+	}
+	definition += kind + " (" + quoteIdents(constraint.Columns) + ")"
+
+	if constraint.Type != "foreign_key" {
+		return definition, nil
+	}
+
+	ref := constraint.References
+	if ref == nil || ref.Table == "" {
+		return "", fmt.Errorf("%w: foreign key needs a referenced table", domain.ErrInvalidRequest)
+	}
+	definition += " REFERENCES " + pgclient.QuoteQualified(schemaOrDefault(ref.Schema), ref.Table)
+	if len(ref.Columns) > 0 {
+		definition += " (" + quoteIdents(ref.Columns) + ")"
+	}
+	for _, clause := range [][2]string{{"ON DELETE", ref.OnDelete}, {"ON UPDATE", ref.OnUpdate}} {
+		if clause[1] == "" {
+			continue
+		}
+		action, ok := referentialActions[clause[1]]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown referential action %q", domain.ErrInvalidRequest, clause[1])
+		}
+		definition += " " + clause[0] + " " + action
+	}
+
+	return definition, nil
+}
+
+func commentDDL(object, comment string) string {
+	if comment == "" {
+		return "COMMENT ON " + object + " IS NULL"
+	}
+
+	return "COMMENT ON " + object + " IS " + pgclient.QuoteLiteral(comment)
+}
+
+func validateType(column, columnType string) error {
+	if !columnTypePattern.MatchString(columnType) {
+		return fmt.Errorf("%w: column %s: invalid type %q", domain.ErrInvalidRequest, column, columnType)
+	}
+
+	return nil
+}
+
+// validateExpression rejects an expression that could escape the clause it is
+// embedded into, such as one carrying a second statement.
+func validateExpression(clause, expr string) error {
+	if err := pgclient.CheckExpression(expr); err != nil {
+		return fmt.Errorf("%w: %s: %v", domain.ErrInvalidRequest, clause, err)
+	}
+
+	return nil
+}
+
+func quoteIdents(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, pgclient.QuoteIdent(name))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func schemaOrDefault(schema string) string {
+	if schema == "" {
+		return defaultSchema
+	}
+
+	return schema
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"errors"
+	"l6/internal/domain"
+	"slices"
+	"testing"
+)
+
+func TestColumnTypePattern(t *testing.T) {
+	valid := []string{
+		"integer",
+		"INT",
+		"text[]",
+		"integer[][]",
+		"integer[3]",
+		"character varying(20)",
+		"varchar(255)",
+		"char(2)",
+		"national character varying(10)",
+		"bit varying(8)",
+		"double precision",
+		"numeric(10, 2)",
+		"numeric(10,-2)",
+		"timestamp",
+		"timestamp(3) with time zone",
+		"timestamp without time zone",
+		"time(6) with time zone",
+		"timestamptz(3)",
+		"interval",
+		"interval day to second",
+		"interval year(2)",
+		"public.mood",
+		`"My Type"`,
+		`public."My Type"[]`,
+		`"a""b"`,
+	}
+	invalid := []string{
+		"",
+		"int PRIMARY KEY",
+		"int not null",
+		"int DEFAULT 1",
+		`text" DEFAULT "x`,
+		`"unbalanced`,
+		"int; DROP TABLE users",
+		"integer -- comment",
+		"numeric(10",
+		"public.mood.extra",
+		"interval minute to day extra",
+		"int with time zone extra",
+	}
+
+	for _, columnType := range valid {
+		if !columnTypePattern.MatchString(columnType) {
+			t.Errorf("%q rejected, want accepted", columnType)
+		}
+	}
+	for _, columnType := range invalid {
+		if columnTypePattern.MatchString(columnType) {
+			t.Errorf("%q accepted, want rejected", columnType)
+		}
+	}
+}
+
+func TestCreateTableDDL(t *testing.T) {
+	comment := "orders of a customer"
+	tests := []struct {
+		name  string
+		table domain.TableDefinition
+		want  []string
+		err   bool
+	}{
+		{
+			name: "columns and constraints",
+			table: domain.TableDefinition{
+				Name: "orders",
+				Columns: []domain.ColumnDefinition{
+					{Name: "id", Type: "bigint", Identity: "always"},
+					{Name: "placed_at", Type: "timestamp(3) with time zone", Default: ptr("now()"), NotNull: true},
+					{Name: "customer_id", Type: "bigint", Comment: &comment},
+				},
+				Constraints: []domain.ConstraintDefinition{
+					{Type: "primary_key", Columns: []string{"id"}},
+					{Name: "orders_customer_fk", Type: "foreign_key", Columns: []string{"customer_id"},
+						References: &domain.ForeignKeyReference{Table: "customers", OnDelete: "cascade"}},
+				},
+			},
+			want: []string{
+				`CREATE TABLE "public"."orders" (
+    "id" bigint GENERATED ALWAYS AS IDENTITY,
+    "placed_at" timestamp(3) with time zone DEFAULT now() NOT NULL,
+    "customer_id" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "orders_customer_fk" FOREIGN KEY ("customer_id") REFERENCES "public"."customers" ON DELETE CASCADE
+)`,
+				`COMMENT ON COLUMN "public"."orders"."customer_id" IS 'orders of a customer'`,
+			},
+		},
+		{
+			name: "constraint clause in the type",
+			table: domain.TableDefinition{
+				Name:    "orders",
+				Columns: []domain.ColumnDefinition{{Name: "id", Type: "int PRIMARY KEY"}},
+			},
+			err: true,
+		},
+		{
+			name: "identity with default",
+			table: domain.TableDefinition{
+				Name:    "orders",
+				Columns: []domain.ColumnDefinition{{Name: "id", Type: "int", Identity: "always", Default: ptr("1")}},
+			},
+			err: true,
+		},
+		{
+			name:  "no columns",
+			table: domain.TableDefinition{Name: "orders"},
+			err:   true,
+		},
+		{
+			name: "second statement in a default",
+			table: domain.TableDefinition{
+				Name:    "orders",
+				Columns: []domain.ColumnDefinition{{Name: "id", Type: "int", Default: ptr("1; DROP TABLE users")}},
+			},
+			err: true,
+		},
+		{
+			name: "check closing its parentheses",
+			table: domain.TableDefinition{
+				Name:        "orders",
+				Columns:     []domain.ColumnDefinition{{Name: "id", Type: "int"}},
+				Constraints: []domain.ConstraintDefinition{{Type: "check", Check: "id > 0) NOT VALID, CHECK (true"}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := createTableDDL(tt.table)
+			if tt.err {
+				if !errors.Is(err, domain.ErrInvalidRequest) {
+					t.Fatalf("got %v, want an invalid request error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(statements, tt.want) {
+				t.Errorf("got\n%q\nwant\n%q", statements, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlterTableDDL(t *testing.T) {
+	notNull := true
+	tests := []struct {
+		name       string
+		alteration domain.TableAlteration
+		want       []string
+		err        bool
+	}{
+		{
+			name: "ordered changes",
+			alteration: domain.TableAlteration{
+				Schema:        "sales",
+				DropColumns:   []string{"legacy"},
+				RenameColumns: []domain.ColumnRename{{From: "qty", To: "quantity"}},
+				AddColumns:    []domain.ColumnDefinition{{Name: "shipped_at", Type: "time(6) with time zone"}},
+				AlterColumns:  []domain.ColumnAlteration{{Name: "quantity", Type: "numeric(10, 2)", NotNull: &notNull}},
+				RenameTo:      "order_lines",
+				Cascade:       true,
+			},
+			want: []string{
+				`ALTER TABLE "sales"."lines" DROP COLUMN "legacy" CASCADE`,
+				`ALTER TABLE "sales"."lines" RENAME COLUMN "qty" TO "quantity"`,
+				`ALTER TABLE "sales"."lines" ADD COLUMN "shipped_at" time(6) with time zone`,
+				`ALTER TABLE "sales"."lines" ALTER COLUMN "quantity" TYPE numeric(10, 2) USING "quantity"::numeric(10, 2)`,
+				`ALTER TABLE "sales"."lines" ALTER COLUMN "quantity" SET NOT NULL`,
+				`ALTER TABLE "sales"."lines" RENAME TO "order_lines"`,
+			},
+		},
+		{
+			name: "default clause in the new type",
+			alteration: domain.TableAlteration{
+				AlterColumns: []domain.ColumnAlteration{{Name: "quantity", Type: "int not null"}},
+			},
+			err: true,
+		},
+		{
+			name: "second statement in a using expression",
+			alteration: domain.TableAlteration{
+				AlterColumns: []domain.ColumnAlteration{{Name: "quantity", Type: "int", Using: "0; DROP TABLE users"}},
+			},
+			err: true,
+		},
+		{
+			name: "comment in a new default",
+			alteration: domain.TableAlteration{
+				AlterColumns: []domain.ColumnAlteration{{Name: "quantity", Default: ptr("0 --")}},
+			},
+			err: true,
+		},
+		{
+			name: "default and drop default",
+			alteration: domain.TableAlteration{
+				AlterColumns: []domain.ColumnAlteration{{Name: "quantity", Default: ptr("0"), DropDefault: true}},
+			},
+			err: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements, err := alterTableDDL("lines", tt.alteration)
+			if tt.err {
+				if !errors.Is(err, domain.ErrInvalidRequest) {
+					t.Fatalf("got %v, want an invalid request error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(statements, tt.want) {
+				t.Errorf("got\n%q\nwant\n%q", statements, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+)
+
+type ExtensionRepository interface {
+	Extensions(ctx context.Context) ([]domain.Extension, error)
+	CreateExtension(ctx context.Context, create domain.ExtensionCreate) error
+	AlterExtension(ctx context.Context, name string, alter domain.ExtensionAlter) error
+	DropExtension(ctx context.Context, name string, cascade bool) error
+}
+
+// Extensions lists the available extensions, or only the installed ones.
+func (s *Service) Extensions(ctx context.Context, installed bool) ([]domain.Extension, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	extensions, err := conn.repo.Extensions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	if !installed {
+		return extensions, nil
+	}
+
+	list := []domain.Extension{}
+	for _, extension := range extensions {
+		if extension.InstalledVersion != nil {
+			list = append(list, extension)
+		}
+	}
+	return list, nil
+}
+
+func (s *Service) CreateExtension(ctx context.Context, create domain.ExtensionCreate) error {
+	if create.Name == "" {
+		return fmt.Errorf("%w: extension name is required", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	extension, err := s.extension(ctx, conn, create.Name)
+	if err != nil {
+		return err
+	}
+	if extension.InstalledVersion != nil {
+		return fmt.Errorf("%w: extension %s is already installed", domain.ErrConflict, create.Name)
+	}
+
+	if err = conn.repo.CreateExtension(ctx, create); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) AlterExtension(ctx context.Context, name string, alter domain.ExtensionAlter) error {
+	if !alter.Update && alter.Version == "" && alter.Schema == "" {
+		return fmt.Errorf("%w: nothing to change", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	extension, err := s.extension(ctx, conn, name)
+	if err != nil {
+		return err
+	}
+	if extension.InstalledVersion == nil {
+		return fmt.Errorf("%w: extension %s is not installed", domain.ErrNotFound, name)
+	}
+	if alter.Schema != "" && !extension.Relocatable {
+		return fmt.Errorf("%w: extension %s cannot be moved to another schema", domain.ErrInvalidRequest, name)
+	}
+
+	if err = conn.repo.AlterExtension(ctx, name, alter); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DropExtension(ctx context.Context, name string, cascade bool) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	extension, err := s.extension(ctx, conn, name)
+	if err != nil {
+		return err
+	}
+	if extension.InstalledVersion == nil {
+		return fmt.Errorf("%w: extension %s is not installed", domain.ErrNotFound, name)
+	}
+
+	if err = conn.repo.DropExtension(ctx, name, cascade); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) extension(ctx context.Context, conn *connection, name string) (domain.Extension, error) {
+	extensions, err := conn.repo.Extensions(ctx)
+	if err != nil {
+		return domain.Extension{}, fmt.Errorf("repo: %w", err)
+	}
+	for _, extension := range extensions {
+		if extension.Name == name {
+			return extension, nil
+		}
+	}
+	return domain.Extension{}, fmt.Errorf("%w: extension %s is not available", domain.ErrNotFound, name)
+}
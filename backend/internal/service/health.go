@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/config"
+	"l6/internal/domain"
+	"sync"
+	"time"
+)
+
+type HealthRepository interface {
+	HealthStats(ctx context.Context, deadRatio float64, longQuery time.Duration) (domain.HealthStats, error)
+}
+
+// wraparoundLimit is the transaction ID age at which Postgres stops accepting writes.
+const wraparoundLimit = 2_000_000_000
+
+var defaultHealthThresholds = config.HealthConfig{
+	CacheHitRatio:      0.95,
+	ConnectionsPercent: 80,
+	ReplicationLag:     30 * time.Second,
+	OldestXact:         15 * time.Minute,
+	WraparoundPercent:  50,
+	DeadTupleRatio:     0.2,
+	LongQuery:          5 * time.Minute,
+	SlotRetainedWAL:    1 << 30,
+}
+
+// healthSamples keeps the previous sample of every connection to compute rates.
+type healthSamples struct {
+	mu      sync.Mutex
+	samples map[string]domain.HealthStats
+}
+
+func (h *healthSamples) swap(id string, stats domain.HealthStats) (domain.HealthStats, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	previous, ok := h.samples[id]
+	h.samples[id] = stats
+
+	return previous, ok
+}
+
+// DatabaseHealth evaluates the health metrics of the current database against the
+// configured thresholds. The overall status is the worst status of the checks.
+func (s *Service) DatabaseHealth(ctx context.Context) (domain.DatabaseHealth, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.DatabaseHealth{}, err
+	}
+	defer conn.release()
+	thresholds := s.healthThresholds()
+
+	stats, err := conn.repo.HealthStats(ctx, thresholds.DeadTupleRatio, thresholds.LongQuery)
+	if err != nil {
+		return domain.DatabaseHealth{}, fmt.Errorf("repo: %w", err)
+	}
+	previous, hasPrevious := s.health.swap(conn.cfg.ID, stats)
+
+	health := domain.DatabaseHealth{
+		CheckedAt:             stats.SampledAt,
+		Database:              stats.Database,
+		Connections:           stats.Connections,
+		MaxConnections:        stats.MaxConnections,
+		InRecovery:            stats.InRecovery,
+		Replicas:              stats.Replicas,
+		ReplicationLagSeconds: stats.ReplicationLagSecs,
+		OldestXactSeconds:     stats.OldestXactSecs,
+		WraparoundDatabase:    stats.FrozenXidDatabase,
+		WraparoundAge:         stats.FrozenXidAge,
+		DeadTuples:            stats.DeadTuples,
+		LongRunningQueries:    stats.LongQueries,
+	}
+
+	if blocks := stats.BlocksHit + stats.BlocksRead; blocks > 0 {
+		ratio := float64(stats.BlocksHit) / float64(blocks)
+		health.CacheHitRatio = &ratio
+	}
+
+	elapsed := stats.SampledAt.Sub(previous.SampledAt).Seconds()
+	// Counters go backwards after a stats reset or a failover, skip the rates then.
+	xacts, prevXacts := stats.Commits+stats.Rollbacks, previous.Commits+previous.Rollbacks
+	if hasPrevious && elapsed > 0 && xacts >= prevXacts {
+		rate := float64(xacts-prevXacts) / elapsed
+		health.TransactionsPerSecond = &rate
+	}
+	if hasPrevious && elapsed > 0 && stats.WALBytes != nil && previous.WALBytes != nil && *stats.WALBytes >= *previous.WALBytes {
+		rate := float64(*stats.WALBytes-*previous.WALBytes) / elapsed
+		health.WALBytesPerSecond = &rate
+	}
+
+	health.Checks = healthChecks(health, thresholds)
+	health.Status = domain.HealthOK
+	for _, check := range health.Checks {
+		if check.Status == domain.HealthWarning {
+			health.Status = domain.HealthWarning
+			break
+		}
+	}
+
+	return health, nil
+}
+
+func healthChecks(h domain.DatabaseHealth, t config.HealthConfig) []domain.HealthCheck {
+	connectionsPercent := 100 * float64(h.Connections) / float64(max(h.MaxConnections, 1))
+	wraparoundPercent := 100 * float64(h.WraparoundAge) / wraparoundLimit
+	deadTables := float64(len(h.DeadTuples))
+	longQueries := float64(len(h.LongRunningQueries))
+
+	return []domain.HealthCheck{
+		evaluate("cache_hit_ratio", h.CacheHitRatio, t.CacheHitRatio, true,
+			"share of blocks read from shared buffers"),
+		evaluate("connections", &connectionsPercent, t.ConnectionsPercent, false,
+			fmt.Sprintf("%d of %d connections in use", h.Connections, h.MaxConnections)),
+		evaluate("replication_lag", h.ReplicationLagSeconds, t.ReplicationLag.Seconds(), false,
+			"seconds behind the primary or of the slowest replica"),
+		evaluate("oldest_transaction", h.OldestXactSeconds, t.OldestXact.Seconds(), false,
+			"age in seconds of the oldest open transaction"),
+		evaluate("wraparound", &wraparoundPercent, t.WraparoundPercent, false,
+			fmt.Sprintf("age(datfrozenxid) of %s in percent of the wraparound limit", h.WraparoundDatabase)),
+		evaluate("dead_tuples", &deadTables, 0, false,
+			fmt.Sprintf("tables with a dead tuple ratio of at least %.2f", t.DeadTupleRatio)),
+		evaluate("long_running_queries", &longQueries, 0, false,
+			fmt.Sprintf("queries running longer than %s", t.LongQuery)),
+	}
+}
+
+// evaluate warns when value exceeds the threshold, or falls below it when below is set.
+// A missing value is reported as unknown.
+func evaluate(name string, value *float64, threshold float64, below bool, message string) domain.HealthCheck {
+	check := domain.HealthCheck{Name: name, Status: domain.HealthOK, Value: value, Threshold: threshold, Message: message}
+
+	switch {
+	case value == nil:
+		check.Status = domain.HealthUnknown
+	case below && *value < threshold, !below && *value > threshold:
+		check.Status = domain.HealthWarning
+	}
+
+	return check
+}
+
+func (s *Service) healthThresholds() config.HealthConfig {
+	t := s.cfg.Health
+	d := defaultHealthThresholds
+
+	if t.CacheHitRatio <= 0 {
+		t.CacheHitRatio = d.CacheHitRatio
+	}
+	if t.ConnectionsPercent <= 0 {
+		t.ConnectionsPercent = d.ConnectionsPercent
+	}
+	if t.ReplicationLag <= 0 {
+		t.ReplicationLag = d.ReplicationLag
+	}
+	if t.OldestXact <= 0 {
+		t.OldestXact = d.OldestXact
+	}
+	if t.WraparoundPercent <= 0 {
+		t.WraparoundPercent = d.WraparoundPercent
+	}
+	if t.DeadTupleRatio <= 0 {
+		t.DeadTupleRatio = d.DeadTupleRatio
+	}
+	if t.LongQuery <= 0 {
+		t.LongQuery = d.LongQuery
+	}
+	if t.SlotRetainedWAL <= 0 {
+		t.SlotRetainedWAL = d.SlotRetainedWAL
+	}
+
+	return t
+}
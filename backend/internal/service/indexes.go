@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+)
+
+type IndexRepository interface {
+	Indexes(ctx context.Context, schemas []string, table string) ([]domain.Index, error)
+	CreateIndex(ctx context.Context, index domain.IndexCreate) error
+	DropIndex(ctx context.Context, schema, name string, concurrently, cascade bool) error
+	ReindexIndex(ctx context.Context, schema, name string, concurrently bool) error
+}
+
+var indexMethods = []string{"btree", "gin", "gist", "brin"}
+
+// Indexes lists the indexes of the given schemas, optionally of a single table,
+// flagging unused and duplicate ones.
+func (s *Service) Indexes(ctx context.Context, schemas []string, table string) ([]domain.Index, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	indexes, err := conn.repo.Indexes(ctx, schemas, table)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return indexes, nil
+}
+
+// CreateIndex queues the build of an index. Builds of large tables outlast a
+// request, so they run as background jobs. A cancelled concurrent build leaves
+// an INVALID index behind that has to be dropped.
+func (s *Service) CreateIndex(ctx context.Context, index domain.IndexCreate) (domain.Job, error) {
+	if index.Table == "" {
+		return domain.Job{}, fmt.Errorf("%w: table is required", domain.ErrInvalidRequest)
+	}
+	if len(index.Columns) == 0 && len(index.Expressions) == 0 {
+		return domain.Job{}, fmt.Errorf("%w: index needs columns or expressions", domain.ErrInvalidRequest)
+	}
+	if index.Method == "" {
+		index.Method = "btree"
+	}
+	if !slices.Contains(indexMethods, index.Method) {
+		return domain.Job{}, fmt.Errorf("%w: index method must be one of %v", domain.ErrInvalidRequest, indexMethods)
+	}
+	if index.Unique && index.Method != "btree" {
+		return domain.Job{}, fmt.Errorf("%w: only btree indexes can be unique", domain.ErrInvalidRequest)
+	}
+	for _, expression := range index.Expressions {
+		if err := validateExpression("index expression", expression); err != nil {
+			return domain.Job{}, err
+		}
+	}
+	if err := validateExpression("WHERE", index.Where); err != nil {
+		return domain.Job{}, err
+	}
+	index.Schema = schemaOrDefault(index.Schema)
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	defer conn.release()
+	spec := domain.Job{Kind: domain.JobIndex, Object: index.Schema + "." + index.Table}
+	return s.startJob(conn, spec, func(ctx context.Context, _ *domain.ToolProgress) (string, any, error) {
+		if err := conn.repo.CreateIndex(ctx, index); err != nil {
+			return "", nil, fmt.Errorf("repo: %w", err)
+		}
+		return "", nil, nil
+	})
+}
+
+func (s *Service) DropIndex(ctx context.Context, schema, name string, concurrently, cascade bool) error {
+	if concurrently && cascade {
+		return fmt.Errorf("%w: an index cannot be dropped concurrently with cascade", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.DropIndex(ctx, schemaOrDefault(schema), name, concurrently, cascade); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+// ReindexIndex queues the rebuild of an index as a background job.
+func (s *Service) ReindexIndex(ctx context.Context, schema, name string, concurrently bool) (domain.Job, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	defer conn.release()
+	schema = schemaOrDefault(schema)
+
+	spec := domain.Job{Kind: domain.JobReindex, Object: schema + "." + name}
+	return s.startJob(conn, spec, func(ctx context.Context, _ *domain.ToolProgress) (string, any, error) {
+		if err := conn.repo.ReindexIndex(ctx, schema, name, concurrently); err != nil {
+			return "", nil, fmt.Errorf("repo: %w", err)
+		}
+		return "", nil, nil
+	})
+}
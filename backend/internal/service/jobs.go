@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultJobWorkers   = 2
+	defaultJobQueue     = 16
+	defaultJobRetention = time.Hour
+)
+
+// jobFunc runs the command of a job and returns the backup file it produced or
+// read, along with an optional result.
+type jobFunc func(ctx context.Context, progress *domain.ToolProgress) (string, any, error)
+
+type job struct {
+	domain.Job
+	run      jobFunc
+	progress *domain.ToolProgress
+	cancel   context.CancelFunc
+	conn     *connection
+}
+
+// jobQueue holds the background jobs. Queued jobs are picked up by the workers
+// started by RunJobs, finished ones are kept for the configured retention.
+type jobQueue struct {
+	mu    sync.Mutex
+	jobs  map[string]*job
+	queue chan *job
+}
+
+func (q *jobQueue) enqueue(j *job, retention time.Duration) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cutoff := time.Now().Add(-retention)
+	for id, old := range q.jobs {
+		if old.FinishedAt != nil && old.FinishedAt.Before(cutoff) {
+			delete(q.jobs, id)
+		}
+	}
+
+	select {
+	case q.queue <- j:
+		q.jobs[j.ID] = j
+		return nil
+	default:
+		return fmt.Errorf("%w: job queue is full, try again later", domain.ErrConflict)
+	}
+}
+
+// snapshot copies the state of a job, computing its elapsed time and the size of
+// the backup written so far.
+func (q *jobQueue) snapshot(j *job) domain.Job {
+	q.mu.Lock()
+	state := j.Job
+	q.mu.Unlock()
+
+	switch {
+	case state.StartedAt == nil:
+	case state.FinishedAt != nil:
+		state.ElapsedSeconds = state.FinishedAt.Sub(*state.StartedAt).Seconds()
+	default:
+		state.ElapsedSeconds = time.Since(*state.StartedAt).Seconds()
+	}
+
+	if state.Kind == domain.JobBackup || state.Kind == domain.JobWipe {
+		var written int64
+		if path := j.progress.Path(); path != "" {
+			if info, err := os.Stat(path); err == nil {
+				written = info.Size()
+				if info.IsDir() {
+					written = dirSize(path)
+				}
+			}
+		}
+		state.BytesWritten = &written
+	}
+	state.Stderr = j.progress.Output()
+
+	return state
+}
+
+func (q *jobQueue) get(id string) (*job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	j, ok := q.jobs[id]
+	return j, ok
+}
+
+// RunJobs runs the queued jobs with the configured number of
+// workers until ctx is done. Running jobs are cancelled with ctx.
+func (s *Service) RunJobs(ctx context.Context, l *slog.Logger) {
+	workers := s.cfg.Jobs.Workers
+	if workers <= 0 {
+		workers = defaultJobWorkers
+	}
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case j := <-s.jobs.queue:
+					s.runJob(ctx, j, l)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *Service) runJob(ctx context.Context, j *job, l *slog.Logger) {
+	defer j.conn.release()
+
+	s.jobs.mu.Lock()
+	if j.State != domain.JobQueued {
+		s.jobs.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	started := time.Now()
+	j.State = domain.JobRunning
+	j.StartedAt = &started
+	j.cancel = cancel
+	s.jobs.mu.Unlock()
+
+	l.Info("Job started", "id", j.ID, "kind", j.Kind, "connection", j.Connection)
+	filename, result, err := j.run(ctx, j.progress)
+
+	s.jobs.mu.Lock()
+	defer s.jobs.mu.Unlock()
+
+	finished := time.Now()
+	j.FinishedAt = &finished
+	if filename != "" {
+		j.Filename = filename
+	}
+	j.Result = result
+	switch {
+	case j.State == domain.JobCancelled:
+		l.Info("Job cancelled", "id", j.ID)
+	case err != nil:
+		j.State = domain.JobFailed
+		j.Error = err.Error()
+		l.Error("Job failed", "id", j.ID, "error", err)
+	default:
+		j.State = domain.JobSucceeded
+		l.Info("Job succeeded", "id", j.ID, "filename", j.Filename)
+	}
+}
+
+// startJob queues a job for the connection. spec names its kind and, depending on
+// the kind, its backup file or object.
+func (s *Service) startJob(conn *connection, spec domain.Job, run jobFunc) (domain.Job, error) {
+	retention := s.cfg.Jobs.Retention
+	if retention <= 0 {
+		retention = defaultJobRetention
+	}
+
+	j := &job{
+		Job: domain.Job{
+			ID:         uuid.NewString(),
+			Kind:       spec.Kind,
+			Connection: conn.cfg.ID,
+			State:      domain.JobQueued,
+			Filename:   spec.Filename,
+			Object:     spec.Object,
+			CreatedAt:  time.Now(),
+		},
+		run:      run,
+		progress: &domain.ToolProgress{},
+		conn:     conn,
+	}
+	// The job keeps the pool open until a worker has taken it off the queue.
+	conn.acquire()
+	if err := s.jobs.enqueue(j, retention); err != nil {
+		conn.release()
+		return domain.Job{}, err
+	}
+
+	return s.jobs.snapshot(j), nil
+}
+
+// Jobs lists the known jobs, newest first.
+func (s *Service) Jobs(_ context.Context) ([]domain.Job, error) {
+	s.jobs.mu.Lock()
+	all := make([]*job, 0, len(s.jobs.jobs))
+	for _, j := range s.jobs.jobs {
+		all = append(all, j)
+	}
+	s.jobs.mu.Unlock()
+
+	jobs := make([]domain.Job, 0, len(all))
+	for _, j := range all {
+		jobs = append(jobs, s.jobs.snapshot(j))
+	}
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].CreatedAt.After(jobs[k].CreatedAt)
+	})
+
+	return jobs, nil
+}
+
+func (s *Service) Job(_ context.Context, id string) (domain.Job, error) {
+	j, ok := s.jobs.get(id)
+	if !ok {
+		return domain.Job{}, fmt.Errorf("%w: job %s", domain.ErrNotFound, id)
+	}
+	return s.jobs.snapshot(j), nil
+}
+
+// CancelJob cancels a queued or running job. A running command is killed and a
+// partially written backup is removed.
+func (s *Service) CancelJob(_ context.Context, id string) (domain.Job, error) {
+	j, ok := s.jobs.get(id)
+	if !ok {
+		return domain.Job{}, fmt.Errorf("%w: job %s", domain.ErrNotFound, id)
+	}
+
+	s.jobs.mu.Lock()
+	switch j.State {
+	case domain.JobQueued:
+		finished := time.Now()
+		j.State = domain.JobCancelled
+		j.FinishedAt = &finished
+	case domain.JobRunning:
+		j.State = domain.JobCancelled
+		j.cancel()
+	default:
+		s.jobs.mu.Unlock()
+		return domain.Job{}, fmt.Errorf("%w: job %s already %s", domain.ErrConflict, id, j.State)
+	}
+	s.jobs.mu.Unlock()
+
+	return s.jobs.snapshot(j), nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+	"strings"
+)
+
+type LogicalReplicationRepository interface {
+	Publications(ctx context.Context) ([]domain.Publication, error)
+	CreatePublication(ctx context.Context, create domain.PublicationCreate) error
+	AlterPublication(ctx context.Context, name string, alter domain.PublicationAlter) error
+	DropPublication(ctx context.Context, name string) error
+	Subscriptions(ctx context.Context) ([]domain.Subscription, error)
+	CreateSubscription(ctx context.Context, create domain.SubscriptionCreate) error
+	AlterSubscription(ctx context.Context, name string, alter domain.SubscriptionAlter) error
+	RefreshSubscription(ctx context.Context, name string, copyData bool) error
+	DropSubscription(ctx context.Context, name string) error
+}
+
+var publishOperations = []string{"insert", "update", "delete", "truncate"}
+
+func (s *Service) Publications(ctx context.Context) ([]domain.Publication, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	publications, err := conn.repo.Publications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return publications, nil
+}
+
+func (s *Service) CreatePublication(ctx context.Context, create domain.PublicationCreate) error {
+	if create.Name == "" {
+		return fmt.Errorf("%w: publication name is required", domain.ErrInvalidRequest)
+	}
+	if create.AllTables && len(create.Tables) > 0 {
+		return fmt.Errorf("%w: all_tables and tables are mutually exclusive", domain.ErrInvalidRequest)
+	}
+	var err error
+	if create.Tables, err = publicationTables(create.Tables); err != nil {
+		return err
+	}
+	if create.Operations, err = publishedOperations(create.Operations); err != nil {
+		return err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.CreatePublication(ctx, create); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) AlterPublication(ctx context.Context, name string, alter domain.PublicationAlter) error {
+	if len(alter.AddTables) == 0 && len(alter.DropTables) == 0 && len(alter.Operations) == 0 && alter.RenameTo == "" {
+		return fmt.Errorf("%w: nothing to change", domain.ErrInvalidRequest)
+	}
+	var err error
+	if alter.AddTables, err = publicationTables(alter.AddTables); err != nil {
+		return err
+	}
+	if alter.DropTables, err = publicationTables(alter.DropTables); err != nil {
+		return err
+	}
+	if alter.Operations, err = publishedOperations(alter.Operations); err != nil {
+		return err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.AlterPublication(ctx, name, alter); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DropPublication(ctx context.Context, name string) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.DropPublication(ctx, name); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) Subscriptions(ctx context.Context) ([]domain.Subscription, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	subscriptions, err := conn.repo.Subscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return subscriptions, nil
+}
+
+func (s *Service) CreateSubscription(ctx context.Context, create domain.SubscriptionCreate) error {
+	if create.Name == "" {
+		return fmt.Errorf("%w: subscription name is required", domain.ErrInvalidRequest)
+	}
+	if create.ConnInfo == "" {
+		return fmt.Errorf("%w: conninfo is required", domain.ErrInvalidRequest)
+	}
+	if len(create.Publications) == 0 {
+		return fmt.Errorf("%w: at least one publication is required", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.CreateSubscription(ctx, create); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) AlterSubscription(ctx context.Context, name string, alter domain.SubscriptionAlter) error {
+	if alter.Enabled == nil && len(alter.Publications) == 0 {
+		return fmt.Errorf("%w: nothing to change", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.AlterSubscription(ctx, name, alter); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) RefreshSubscription(ctx context.Context, name string, copyData bool) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.RefreshSubscription(ctx, name, copyData); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DropSubscription(ctx context.Context, name string) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.DropSubscription(ctx, name); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+// publicationTables defaults the schema of the tables and rejects unnamed ones.
+func publicationTables(tables []domain.PublicationTable) ([]domain.PublicationTable, error) {
+	for i := range tables {
+		if tables[i].Table == "" {
+			return nil, fmt.Errorf("%w: table name is required", domain.ErrInvalidRequest)
+		}
+		tables[i].Schema = schemaOrDefault(tables[i].Schema)
+	}
+	return tables, nil
+}
+
+// publishedOperations lowercases the operations and rejects unknown or repeated ones.
+func publishedOperations(operations []string) ([]string, error) {
+	normalized := make([]string, 0, len(operations))
+	for _, operation := range operations {
+		operation = strings.ToLower(operation)
+		if !slices.Contains(publishOperations, operation) {
+			return nil, fmt.Errorf("%w: operations must be among %v", domain.ErrInvalidRequest, publishOperations)
+		}
+		if slices.Contains(normalized, operation) {
+			return nil, fmt.Errorf("%w: duplicate operation %q", domain.ErrInvalidRequest, operation)
+		}
+		normalized = append(normalized, operation)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"l6/internal/domain"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// migrationFilePattern matches <version>_<name>.up.sql and <version>_<name>.down.sql.
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+type MigrationRepository interface {
+	AppliedMigrations(ctx context.Context) ([]domain.AppliedMigration, error)
+	RunMigrations(ctx context.Context, steps []domain.MigrationStep) ([]domain.MigrationExecuted, error)
+}
+
+type migrationFile struct {
+	version  int64
+	name     string
+	checksum string
+	up       string
+	down     string
+}
+
+// MigrationStatus lists the migrations of the migrations directory merged with
+// the ones recorded in the tracking table of the current connection.
+func (s *Service) MigrationStatus(ctx context.Context) ([]domain.Migration, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	files, err := s.loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := conn.repo.AppliedMigrations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+
+	return migrationStatus(files, applied), nil
+}
+
+// MigrateUp applies pending migrations in version order up to and including to,
+// or all of them when to is nil. It refuses to run when an applied migration was modified.
+func (s *Service) MigrateUp(ctx context.Context, to *int64) (domain.MigrationRun, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	defer conn.release()
+	files, err := s.loadMigrations()
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	applied, err := conn.repo.AppliedMigrations(ctx)
+	if err != nil {
+		return domain.MigrationRun{}, fmt.Errorf("repo: %w", err)
+	}
+
+	var steps []domain.MigrationStep
+	for _, m := range migrationStatus(files, applied) {
+		if m.Modified {
+			return domain.MigrationRun{}, fmt.Errorf("%w: migration %d_%s was modified after it had been applied", domain.ErrConflict, m.Version, m.Name)
+		}
+		if m.Applied || m.Missing || (to != nil && m.Version > *to) {
+			continue
+		}
+		file := files[m.Version]
+		steps = append(steps, domain.MigrationStep{
+			Version:  file.version,
+			Name:     file.name,
+			Checksum: file.checksum,
+			SQL:      file.up,
+		})
+	}
+
+	return runMigrations(ctx, conn, steps)
+}
+
+// MigrateDown rolls back applied migrations newer than to in reverse order,
+// or only the latest one when to is nil.
+func (s *Service) MigrateDown(ctx context.Context, to *int64) (domain.MigrationRun, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	defer conn.release()
+	files, err := s.loadMigrations()
+	if err != nil {
+		return domain.MigrationRun{}, err
+	}
+	applied, err := conn.repo.AppliedMigrations(ctx)
+	if err != nil {
+		return domain.MigrationRun{}, fmt.Errorf("repo: %w", err)
+	}
+
+	var steps []domain.MigrationStep
+	for i := len(applied) - 1; i >= 0; i-- {
+		m := applied[i]
+		if to != nil && m.Version <= *to {
+			break
+		}
+
+		file, ok := files[m.Version]
+		if !ok || file.down == "" {
+			return domain.MigrationRun{}, fmt.Errorf("%w: migration %d_%s has no down script", domain.ErrInvalidRequest, m.Version, m.Name)
+		}
+		steps = append(steps, domain.MigrationStep{
+			Version: m.Version,
+			Name:    m.Name,
+			SQL:     file.down,
+			Down:    true,
+		})
+
+		if to == nil {
+			break
+		}
+	}
+
+	return runMigrations(ctx, conn, steps)
+}
+
+// runMigrations runs the steps in order. When a step fails, the returned run lists
+// the steps executed before it along with the error.
+func runMigrations(ctx context.Context, conn *connection, steps []domain.MigrationStep) (domain.MigrationRun, error) {
+	if len(steps) == 0 {
+		return domain.MigrationRun{Executed: []domain.MigrationExecuted{}, Message: "Nothing to migrate", Success: true}, nil
+	}
+
+	executed, err := conn.repo.RunMigrations(ctx, steps)
+	if err != nil {
+		if executed == nil {
+			executed = []domain.MigrationExecuted{}
+		}
+		return domain.MigrationRun{
+			Executed: executed,
+			Message:  fmt.Sprintf("%d migration(s) executed before the failure", len(executed)),
+		}, fmt.Errorf("repo: %w", err)
+	}
+
+	return domain.MigrationRun{
+		Executed: executed,
+		Message:  fmt.Sprintf("%d migration(s) executed", len(executed)),
+		Success:  true,
+	}, nil
+}
+
+func (s *Service) loadMigrations() (map[int64]migrationFile, error) {
+	if s.cfg.MigrationsDir == "" {
+		return nil, fmt.Errorf("%w: migrations directory is not configured", domain.ErrInvalidRequest)
+	}
+
+	entries, err := os.ReadDir(s.cfg.MigrationsDir)
+	if err != nil {
+		return nil, fmt.Errorf("read migrations directory: %w", err)
+	}
+
+	files := make(map[int64]migrationFile)
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: %w", entry.Name(), err)
+		}
+
+		file := files[version]
+		if file.name != "" && file.name != match[2] {
+			return nil, fmt.Errorf("migrations %d_%s and %d_%s share a version", version, file.name, version, match[2])
+		}
+		file.version = version
+		file.name = match[2]
+
+		content, err := os.ReadFile(filepath.Join(s.cfg.MigrationsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read migration: %w", err)
+		}
+		if match[3] == "up" {
+			sum := sha256.Sum256(content)
+			file.checksum = hex.EncodeToString(sum[:])
+			file.up = string(content)
+		} else {
+			file.down = string(content)
+		}
+		files[version] = file
+	}
+
+	for version, file := range files {
+		if file.up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", version, file.name)
+		}
+	}
+
+	return files, nil
+}
+
+func migrationStatus(files map[int64]migrationFile, applied []domain.AppliedMigration) []domain.Migration {
+	byVersion := make(map[int64]domain.Migration, len(files)+len(applied))
+
+	for _, file := range files {
+		byVersion[file.version] = domain.Migration{
+			Version:  file.version,
+			Name:     file.name,
+			Checksum: file.checksum,
+			HasDown:  file.down != "",
+		}
+	}
+
+	for _, a := range applied {
+		m, ok := byVersion[a.Version]
+		if !ok {
+			m = domain.Migration{Version: a.Version, Name: a.Name, Checksum: a.Checksum, Missing: true}
+		}
+		m.Applied = true
+		m.AppliedAt = &a.AppliedAt
+		m.Modified = ok && m.Checksum != a.Checksum
+		byVersion[a.Version] = m
+	}
+
+	migrations := make([]domain.Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+)
+
+type ObjectRepository interface {
+	Views(ctx context.Context, schemas []string) ([]domain.View, error)
+	View(ctx context.Context, schema, name string) (domain.View, error)
+	RefreshMaterializedView(ctx context.Context, schema, name string, concurrently bool) error
+	Functions(ctx context.Context, schemas []string) ([]domain.Function, error)
+	Function(ctx context.Context, schema, name string) ([]domain.Function, error)
+	Triggers(ctx context.Context, schemas []string, table string) ([]domain.Trigger, error)
+}
+
+func (s *Service) Views(ctx context.Context, schemas []string) ([]domain.View, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	views, err := conn.repo.Views(ctx, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return views, nil
+}
+
+func (s *Service) View(ctx context.Context, schema, name string) (domain.View, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.View{}, err
+	}
+	defer conn.release()
+	view, err := conn.repo.View(ctx, schemaOrDefault(schema), name)
+	if err != nil {
+		return domain.View{}, fmt.Errorf("repo: %w", err)
+	}
+	return view, nil
+}
+
+// RefreshMaterializedView queues the refresh of a materialized view as a
+// background job. A concurrent refresh needs a populated view with a unique index.
+func (s *Service) RefreshMaterializedView(ctx context.Context, schema, name string, concurrently bool) (domain.Job, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	defer conn.release()
+	schema = schemaOrDefault(schema)
+
+	view, err := conn.repo.View(ctx, schema, name)
+	if err != nil {
+		return domain.Job{}, fmt.Errorf("repo: %w", err)
+	}
+	if !view.Materialized {
+		return domain.Job{}, fmt.Errorf("%w: %s.%s is not a materialized view", domain.ErrInvalidRequest, schema, name)
+	}
+	if concurrently && !view.Populated {
+		return domain.Job{}, fmt.Errorf("%w: %s.%s is not populated and cannot be refreshed concurrently", domain.ErrInvalidRequest, schema, name)
+	}
+
+	spec := domain.Job{Kind: domain.JobRefresh, Object: schema + "." + name}
+	return s.startJob(conn, spec, func(ctx context.Context, _ *domain.ToolProgress) (string, any, error) {
+		if err := conn.repo.RefreshMaterializedView(ctx, schema, name, concurrently); err != nil {
+			return "", nil, fmt.Errorf("repo: %w", err)
+		}
+		return "", nil, nil
+	})
+}
+
+func (s *Service) Functions(ctx context.Context, schemas []string) ([]domain.Function, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	functions, err := conn.repo.Functions(ctx, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return functions, nil
+}
+
+func (s *Service) Function(ctx context.Context, schema, name string) ([]domain.Function, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	functions, err := conn.repo.Function(ctx, schemaOrDefault(schema), name)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return functions, nil
+}
+
+func (s *Service) Triggers(ctx context.Context, schemas []string, table string) ([]domain.Trigger, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	triggers, err := conn.repo.Triggers(ctx, schemas, table)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return triggers, nil
+}
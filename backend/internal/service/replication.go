@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"time"
+)
+
+type ReplicationRepository interface {
+	ReplicationStatus(ctx context.Context) (domain.ReplicationStatus, error)
+}
+
+// ReplicationStatus reports whether the server is a primary or a standby with its
+// replicas, slots and WAL receiver. Inactive slots, slots retaining more WAL than
+// the configured threshold and lagging replicas are warned about.
+func (s *Service) ReplicationStatus(ctx context.Context) (domain.ReplicationStatus, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.ReplicationStatus{}, err
+	}
+	defer conn.release()
+	status, err := conn.repo.ReplicationStatus(ctx)
+	if err != nil {
+		return domain.ReplicationStatus{}, fmt.Errorf("repo: %w", err)
+	}
+
+	status.Role = "primary"
+	if status.InRecovery {
+		status.Role = "standby"
+	}
+
+	t := s.healthThresholds()
+	status.Warnings = []string{}
+	for _, slot := range status.Slots {
+		retained := "an unknown amount of WAL"
+		if slot.RetainedWALBytes != nil {
+			retained = fmt.Sprintf("%d bytes of WAL", *slot.RetainedWALBytes)
+		}
+		switch {
+		case slot.WALStatus != nil && *slot.WALStatus == "lost":
+			status.Warnings = append(status.Warnings, fmt.Sprintf("slot %s has lost required WAL and can no longer be used", slot.Name))
+		case !slot.Active:
+			status.Warnings = append(status.Warnings, fmt.Sprintf("slot %s is inactive and retains %s", slot.Name, retained))
+		case slot.RetainedWALBytes != nil && *slot.RetainedWALBytes >= t.SlotRetainedWAL:
+			status.Warnings = append(status.Warnings, fmt.Sprintf("slot %s retains %s", slot.Name, retained))
+		}
+	}
+	for _, replica := range status.Replicas {
+		if replica.ReplayLagSeconds != nil && *replica.ReplayLagSeconds >= t.ReplicationLag.Seconds() {
+			status.Warnings = append(status.Warnings, fmt.Sprintf("replica %s replays %s behind",
+				replica.ApplicationName, time.Duration(*replica.ReplayLagSeconds*float64(time.Second)).Round(time.Second)))
+		}
+	}
+	if status.InRecovery && status.WALReceiver == nil {
+		status.Warnings = append(status.Warnings, "standby is not streaming from a primary")
+	}
+
+	return status, nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+	"strings"
+)
+
+type RLSRepository interface {
+	TablesRLS(ctx context.Context, schemas []string) ([]domain.TableRLS, error)
+	Policies(ctx context.Context, schemas []string, table string) ([]domain.Policy, error)
+	CreatePolicy(ctx context.Context, schema, table string, policy domain.PolicyCreate) error
+	AlterPolicy(ctx context.Context, schema, table, name string, alter domain.PolicyAlter) error
+	DropPolicy(ctx context.Context, schema, table, name string) error
+	SetTableRLS(ctx context.Context, schema, table string, change domain.RLSChange) error
+}
+
+var policyCommands = []string{"ALL", "SELECT", "INSERT", "UPDATE", "DELETE"}
+
+func (s *Service) TablesRLS(ctx context.Context, schemas []string) ([]domain.TableRLS, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	tables, err := conn.repo.TablesRLS(ctx, schemas)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return tables, nil
+}
+
+func (s *Service) Policies(ctx context.Context, schemas []string, table string) ([]domain.Policy, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	policies, err := conn.repo.Policies(ctx, schemas, table)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return policies, nil
+}
+
+// CreatePolicy creates a policy on a table. INSERT policies only take WITH CHECK,
+// SELECT and DELETE policies only take USING.
+func (s *Service) CreatePolicy(ctx context.Context, schema, table string, policy domain.PolicyCreate) error {
+	if policy.Name == "" {
+		return fmt.Errorf("%w: policy name is required", domain.ErrInvalidRequest)
+	}
+	policy.Command = strings.ToUpper(policy.Command)
+	if policy.Command == "" {
+		policy.Command = "ALL"
+	}
+	if !slices.Contains(policyCommands, policy.Command) {
+		return fmt.Errorf("%w: policy command must be one of %v", domain.ErrInvalidRequest, policyCommands)
+	}
+	if policy.Command == "INSERT" && policy.Using != "" {
+		return fmt.Errorf("%w: INSERT policies only take a WITH CHECK expression", domain.ErrInvalidRequest)
+	}
+	if (policy.Command == "SELECT" || policy.Command == "DELETE") && policy.WithCheck != "" {
+		return fmt.Errorf("%w: %s policies only take a USING expression", domain.ErrInvalidRequest, policy.Command)
+	}
+	if err := validateExpression("USING", policy.Using); err != nil {
+		return err
+	}
+	if err := validateExpression("WITH CHECK", policy.WithCheck); err != nil {
+		return err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.CreatePolicy(ctx, schemaOrDefault(schema), table, policy); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) AlterPolicy(ctx context.Context, schema, table, name string, alter domain.PolicyAlter) error {
+	if len(alter.Roles) == 0 && alter.Using == nil && alter.WithCheck == nil && alter.RenameTo == "" {
+		return fmt.Errorf("%w: no changes requested", domain.ErrInvalidRequest)
+	}
+	if alter.Using != nil {
+		if err := validateExpression("USING", *alter.Using); err != nil {
+			return err
+		}
+	}
+	if alter.WithCheck != nil {
+		if err := validateExpression("WITH CHECK", *alter.WithCheck); err != nil {
+			return err
+		}
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.AlterPolicy(ctx, schemaOrDefault(schema), table, name, alter); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DropPolicy(ctx context.Context, schema, table, name string) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.DropPolicy(ctx, schemaOrDefault(schema), table, name); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) SetTableRLS(ctx context.Context, schema, table string, change domain.RLSChange) error {
+	if change.Enabled == nil && change.Forced == nil {
+		return fmt.Errorf("%w: no changes requested", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.SetTableRLS(ctx, schemaOrDefault(schema), table, change); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type RoleRepository interface {
+	Roles(ctx context.Context) ([]domain.Role, error)
+	CreateRole(ctx context.Context, role domain.RoleCreate) error
+	AlterRole(ctx context.Context, name string, alter domain.RoleAlter) error
+	SetRolePassword(ctx context.Context, name, password string) error
+	DropRole(ctx context.Context, name string) error
+	GrantPrivileges(ctx context.Context, change domain.PrivilegeChange) error
+	RevokePrivileges(ctx context.Context, change domain.PrivilegeChange) error
+	Privileges(ctx context.Context, schemas []string) ([]domain.PrivilegeGrant, error)
+}
+
+// objectPrivileges lists the privileges that can be granted per object type.
+var objectPrivileges = map[string][]string{
+	"schema":   {"USAGE", "CREATE", "ALL"},
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER", "ALL"},
+	"sequence": {"USAGE", "SELECT", "UPDATE", "ALL"},
+}
+
+func (s *Service) Roles(ctx context.Context) ([]domain.Role, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	roles, err := conn.repo.Roles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return roles, nil
+}
+
+func (s *Service) CreateRole(ctx context.Context, role domain.RoleCreate) error {
+	if role.Name == "" {
+		return fmt.Errorf("%w: role name is required", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.CreateRole(ctx, role); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+// AlterRole changes the attributes and memberships of a role. The role the
+// connection logs in with cannot be renamed.
+func (s *Service) AlterRole(ctx context.Context, name string, alter domain.RoleAlter) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if alter.RenameTo != "" && name == conn.cfg.Postgres.Username {
+		return fmt.Errorf("%w: role %s is used by the connection and cannot be renamed", domain.ErrInvalidRequest, name)
+	}
+	if alter.RoleAttributes == (domain.RoleAttributes{}) && len(alter.GrantRoles) == 0 &&
+		len(alter.RevokeRoles) == 0 && alter.RenameTo == "" {
+		return fmt.Errorf("%w: no changes requested", domain.ErrInvalidRequest)
+	}
+
+	if err = conn.repo.AlterRole(ctx, name, alter); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) SetRolePassword(ctx context.Context, name, password string) error {
+	if password == "" {
+		return fmt.Errorf("%w: password is required", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.SetRolePassword(ctx, name, password); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) DropRole(ctx context.Context, name string) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if name == conn.cfg.Postgres.Username {
+		return fmt.Errorf("%w: role %s is used by the connection and cannot be dropped", domain.ErrInvalidRequest, name)
+	}
+
+	if err = conn.repo.DropRole(ctx, name); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) GrantPrivileges(ctx context.Context, change domain.PrivilegeChange) error {
+	if err := normalizePrivilegeChange(&change); err != nil {
+		return err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.GrantPrivileges(ctx, change); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) RevokePrivileges(ctx context.Context, change domain.PrivilegeChange) error {
+	if err := normalizePrivilegeChange(&change); err != nil {
+		return err
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.RevokePrivileges(ctx, change); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+// PrivilegeMatrix returns the privileges of every grantee on the schemas, tables
+// and sequences of the given schemas, optionally limited to one object type.
+func (s *Service) PrivilegeMatrix(ctx context.Context, schemas []string, objectType string) (domain.PrivilegeMatrix, error) {
+	if _, ok := objectPrivileges[objectType]; objectType != "" && !ok {
+		return domain.PrivilegeMatrix{}, fmt.Errorf("%w: unknown object type %q", domain.ErrInvalidRequest, objectType)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.PrivilegeMatrix{}, err
+	}
+	defer conn.release()
+	grants, err := conn.repo.Privileges(ctx, schemas)
+	if err != nil {
+		return domain.PrivilegeMatrix{}, fmt.Errorf("repo: %w", err)
+	}
+
+	matrix := domain.PrivilegeMatrix{Grantees: []string{}, Objects: []domain.PrivilegeObject{}}
+	index := make(map[string]int)
+	for _, grant := range grants {
+		if objectType != "" && grant.ObjectType != objectType {
+			continue
+		}
+		if !slices.Contains(matrix.Grantees, grant.Grantee) {
+			matrix.Grantees = append(matrix.Grantees, grant.Grantee)
+		}
+
+		key := grant.ObjectType + "/" + grant.Schema + "/" + grant.Name
+		i, ok := index[key]
+		if !ok {
+			i = len(matrix.Objects)
+			index[key] = i
+			matrix.Objects = append(matrix.Objects, domain.PrivilegeObject{
+				ObjectType: grant.ObjectType,
+				Schema:     grant.Schema,
+				Name:       grant.Name,
+				Owner:      grant.Owner,
+				Privileges: make(map[string][]string),
+				Grantable:  make(map[string][]string),
+			})
+		}
+		matrix.Objects[i].Privileges[grant.Grantee] = grant.Privileges
+		if len(grant.Grantable) > 0 {
+			matrix.Objects[i].Grantable[grant.Grantee] = grant.Grantable
+		}
+	}
+	sort.Strings(matrix.Grantees)
+
+	return matrix, nil
+}
+
+func normalizePrivilegeChange(change *domain.PrivilegeChange) error {
+	allowed, ok := objectPrivileges[change.ObjectType]
+	if !ok {
+		return fmt.Errorf("%w: object type must be schema, table or sequence", domain.ErrInvalidRequest)
+	}
+	if change.ObjectType == "schema" {
+		if change.Schema == "" {
+			return fmt.Errorf("%w: schema is required", domain.ErrInvalidRequest)
+		}
+		if len(change.Objects) > 0 {
+			return fmt.Errorf("%w: objects are not used for schema privileges", domain.ErrInvalidRequest)
+		}
+	}
+	change.Schema = schemaOrDefault(change.Schema)
+
+	if len(change.Privileges) == 0 || len(change.Roles) == 0 {
+		return fmt.Errorf("%w: privileges and roles are required", domain.ErrInvalidRequest)
+	}
+	for i, privilege := range change.Privileges {
+		privilege = strings.ToUpper(strings.TrimSpace(privilege))
+		if !slices.Contains(allowed, privilege) {
+			return fmt.Errorf("%w: privilege %q cannot be granted on a %s", domain.ErrInvalidRequest, privilege, change.ObjectType)
+		}
+		change.Privileges[i] = privilege
+	}
+	for _, role := range change.Roles {
+		if role == "" {
+			return fmt.Errorf("%w: role names cannot be empty", domain.ErrInvalidRequest)
+		}
+	}
+
+	return nil
+}
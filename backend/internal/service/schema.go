@@ -0,0 +1,621 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"l6/pkg/pgclient"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+type SchemaRepository interface {
+	SchemaSnapshot(ctx context.Context, schemas []string) (domain.SchemaSnapshot, error)
+}
+
+const (
+	actionCreate = "create"
+	actionDrop   = "drop"
+	actionAlter  = "alter"
+)
+
+var changeSigns = map[string]string{actionCreate: "+", actionDrop: "-", actionAlter: "~"}
+
+// Migration phases. Statements are emitted phase by phase so that dependent
+// objects are dropped before and created after the objects they depend on.
+// Functions are created after tables, as SQL function bodies are checked
+// against the tables they reference.
+const (
+	phaseDropTriggers = iota
+	phaseDropViews
+	phaseDropForeignKeys
+	phaseDropConstraints
+	phaseDropIndexes
+	phaseDropTables
+	phaseDropFunctions
+	phaseDropSequences
+	phaseEnums
+	phaseSequences
+	phaseTables
+	phaseColumns
+	phaseFunctions
+	phaseConstraints
+	phaseForeignKeys
+	phaseIndexes
+	phaseViews
+	phaseTriggers
+	phaseDropEnums
+	phaseCount
+)
+
+func (s *Service) SchemaSnapshot(ctx context.Context, schemas []string) (domain.SchemaSnapshot, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.SchemaSnapshot{}, err
+	}
+	defer conn.release()
+	return takeSnapshot(ctx, conn, schemas)
+}
+
+// DiffSchemas compares two schemas and generates the script migrating "from" into "to".
+// A source without a snapshot is captured from its connection, or from the
+// connection of the request when none is named.
+func (s *Service) DiffSchemas(ctx context.Context, from, to domain.SchemaSource, schemas []string) (domain.SchemaDiff, error) {
+	fromSnapshot, err := s.resolveSnapshot(ctx, from, schemas)
+	if err != nil {
+		return domain.SchemaDiff{}, fmt.Errorf("from: %w", err)
+	}
+	toSnapshot, err := s.resolveSnapshot(ctx, to, schemas)
+	if err != nil {
+		return domain.SchemaDiff{}, fmt.Errorf("to: %w", err)
+	}
+
+	return diffSnapshots(fromSnapshot, toSnapshot), nil
+}
+
+func (s *Service) resolveSnapshot(ctx context.Context, source domain.SchemaSource, schemas []string) (domain.SchemaSnapshot, error) {
+	if source.Snapshot != nil {
+		return *source.Snapshot, nil
+	}
+
+	id := source.Connection
+	if id == "" {
+		id = domain.ConnectionFromContext(ctx)
+	}
+	conn, err := s.connections.open(ctx, id)
+	if err != nil {
+		return domain.SchemaSnapshot{}, err
+	}
+	defer conn.release()
+
+	return takeSnapshot(ctx, conn, schemas)
+}
+
+func takeSnapshot(ctx context.Context, conn *connection, schemas []string) (domain.SchemaSnapshot, error) {
+	snapshot, err := conn.repo.SchemaSnapshot(ctx, schemas)
+	if err != nil {
+		return domain.SchemaSnapshot{}, fmt.Errorf("repo: %w", err)
+	}
+
+	snapshot.Connection = conn.cfg.ID
+	snapshot.Database = conn.cfg.Postgres.Database
+	snapshot.TakenAt = time.Now().UTC()
+
+	return snapshot, nil
+}
+
+type schemaDiff struct {
+	changes []domain.SchemaChange
+	phases  [phaseCount][]string
+}
+
+func (d *schemaDiff) change(action, kind, name, detail string) {
+	d.changes = append(d.changes, domain.SchemaChange{Action: action, Kind: kind, Name: name, Detail: detail})
+}
+
+func (d *schemaDiff) sql(phase int, format string, args ...any) {
+	d.phases[phase] = append(d.phases[phase], fmt.Sprintf(format, args...)+";")
+}
+
+// note adds a comment for a change that cannot be migrated automatically.
+func (d *schemaDiff) note(phase int, format string, args ...any) {
+	d.phases[phase] = append(d.phases[phase], "-- "+fmt.Sprintf(format, args...))
+}
+
+func diffSnapshots(from, to domain.SchemaSnapshot) domain.SchemaDiff {
+	d := &schemaDiff{}
+
+	diffEnums(d, from.Enums, to.Enums)
+	diffSequences(d, from.Sequences, to.Sequences)
+	diffFunctions(d, from.Functions, to.Functions)
+	diffTables(d, from.Tables, to.Tables)
+	diffViews(d, from.Views, to.Views)
+	diffTriggers(d, from.Triggers, to.Triggers)
+
+	var text strings.Builder
+	for _, c := range d.changes {
+		fmt.Fprintf(&text, "%s %s %s", changeSigns[c.Action], c.Kind, c.Name)
+		if c.Detail != "" {
+			text.WriteString(": " + c.Detail)
+		}
+		text.WriteString("\n")
+	}
+
+	var migration strings.Builder
+	fmt.Fprintf(&migration, "-- Migration from %s to %s\n", snapshotLabel(from), snapshotLabel(to))
+	if len(d.changes) == 0 {
+		migration.WriteString("-- Schemas are identical\n")
+	} else {
+		migration.WriteString("BEGIN;\n\n")
+		for _, statements := range d.phases {
+			for _, statement := range statements {
+				migration.WriteString(statement + "\n")
+			}
+		}
+		migration.WriteString("\nCOMMIT;\n")
+	}
+
+	changes := d.changes
+	if changes == nil {
+		changes = []domain.SchemaChange{}
+	}
+
+	return domain.SchemaDiff{
+		Changes:   changes,
+		Diff:      text.String(),
+		Migration: migration.String(),
+	}
+}
+
+func snapshotLabel(s domain.SchemaSnapshot) string {
+	label := s.Database
+	if s.Connection != "" {
+		label = s.Connection + "/" + label
+	}
+	if !s.TakenAt.IsZero() {
+		label += " at " + s.TakenAt.Format(time.RFC3339)
+	}
+
+	return label
+}
+
+// byKey indexes items by key and returns the sorted union of keys of both sides.
+func byKey[T any](from, to []T, key func(T) string) (map[string]T, map[string]T, []string) {
+	fromMap := make(map[string]T, len(from))
+	toMap := make(map[string]T, len(to))
+	keys := make([]string, 0, len(from)+len(to))
+
+	for _, item := range from {
+		fromMap[key(item)] = item
+		keys = append(keys, key(item))
+	}
+	for _, item := range to {
+		if _, ok := fromMap[key(item)]; !ok {
+			keys = append(keys, key(item))
+		}
+		toMap[key(item)] = item
+	}
+	sort.Strings(keys)
+
+	return fromMap, toMap, keys
+}
+
+func diffEnums(d *schemaDiff, from, to []domain.SnapshotEnum) {
+	fromMap, toMap, keys := byKey(from, to, func(e domain.SnapshotEnum) string { return e.Schema + "." + e.Name })
+
+	for _, key := range keys {
+		oldEnum, inFrom := fromMap[key]
+		newEnum, inTo := toMap[key]
+		name := pgclient.QuoteQualified(newEnum.Schema, newEnum.Name)
+
+		switch {
+		case !inTo:
+			d.change(actionDrop, "enum", key, "")
+			d.sql(phaseDropEnums, "DROP TYPE %s", pgclient.QuoteQualified(oldEnum.Schema, oldEnum.Name))
+		case !inFrom:
+			d.change(actionCreate, "enum", key, strings.Join(newEnum.Labels, ", "))
+			d.sql(phaseEnums, "CREATE TYPE %s AS ENUM (%s)", name, quoteLiterals(newEnum.Labels))
+		case !slices.Equal(oldEnum.Labels, newEnum.Labels):
+			d.change(actionAlter, "enum", key,
+				fmt.Sprintf("labels %s -> %s", strings.Join(oldEnum.Labels, ", "), strings.Join(newEnum.Labels, ", ")))
+			for i, label := range newEnum.Labels {
+				if slices.Contains(oldEnum.Labels, label) {
+					continue
+				}
+				switch {
+				case i > 0:
+					d.sql(phaseEnums, "ALTER TYPE %s ADD VALUE %s AFTER %s",
+						name, pgclient.QuoteLiteral(label), pgclient.QuoteLiteral(newEnum.Labels[i-1]))
+				case len(oldEnum.Labels) > 0:
+					d.sql(phaseEnums, "ALTER TYPE %s ADD VALUE %s BEFORE %s",
+						name, pgclient.QuoteLiteral(label), pgclient.QuoteLiteral(oldEnum.Labels[0]))
+				default:
+					d.sql(phaseEnums, "ALTER TYPE %s ADD VALUE %s", name, pgclient.QuoteLiteral(label))
+				}
+			}
+			for _, label := range oldEnum.Labels {
+				if !slices.Contains(newEnum.Labels, label) {
+					d.note(phaseEnums, "enum %s: value %s cannot be removed automatically", key, pgclient.QuoteLiteral(label))
+				}
+			}
+		}
+	}
+}
+
+func diffSequences(d *schemaDiff, from, to []domain.SnapshotSequence) {
+	fromMap, toMap, keys := byKey(from, to, func(s domain.SnapshotSequence) string { return s.Schema + "." + s.Name })
+
+	for _, key := range keys {
+		oldSeq, inFrom := fromMap[key]
+		newSeq, inTo := toMap[key]
+
+		switch {
+		case !inTo:
+			d.change(actionDrop, "sequence", key, "")
+			// A sequence owned by a serial column is already gone with its table or column.
+			d.sql(phaseDropSequences, "DROP SEQUENCE IF EXISTS %s", pgclient.QuoteQualified(oldSeq.Schema, oldSeq.Name))
+		case !inFrom:
+			d.change(actionCreate, "sequence", key, "")
+			d.sql(phaseSequences, "CREATE SEQUENCE %s %s", pgclient.QuoteQualified(newSeq.Schema, newSeq.Name), sequenceOptions(newSeq))
+		case oldSeq != newSeq:
+			d.change(actionAlter, "sequence", key, "options changed")
+			d.sql(phaseSequences, "ALTER SEQUENCE %s %s", pgclient.QuoteQualified(newSeq.Schema, newSeq.Name), sequenceOptions(newSeq))
+		}
+	}
+}
+
+func sequenceOptions(s domain.SnapshotSequence) string {
+	cycle := "NO CYCLE"
+	if s.Cycle {
+		cycle = "CYCLE"
+	}
+
+	return fmt.Sprintf("AS %s INCREMENT BY %d MINVALUE %d MAXVALUE %d START WITH %d %s",
+		s.DataType, s.Increment, s.Min, s.Max, s.Start, cycle)
+}
+
+func diffFunctions(d *schemaDiff, from, to []domain.SnapshotFunction) {
+	key := func(f domain.SnapshotFunction) string { return fmt.Sprintf("%s.%s(%s)", f.Schema, f.Name, f.Arguments) }
+	fromMap, toMap, keys := byKey(from, to, key)
+
+	for _, k := range keys {
+		oldFn, inFrom := fromMap[k]
+		newFn, inTo := toMap[k]
+
+		switch {
+		case !inTo:
+			d.change(actionDrop, oldFn.Kind, k, "")
+			d.sql(phaseDropFunctions, "DROP %s %s(%s)",
+				strings.ToUpper(oldFn.Kind), pgclient.QuoteQualified(oldFn.Schema, oldFn.Name), oldFn.Arguments)
+		case !inFrom:
+			d.change(actionCreate, newFn.Kind, k, "")
+			d.sql(phaseFunctions, "%s", trimStatement(newFn.Definition))
+		case oldFn.Definition != newFn.Definition:
+			d.change(actionAlter, newFn.Kind, k, "definition changed")
+			d.sql(phaseFunctions, "%s", trimStatement(newFn.Definition))
+		}
+	}
+}
+
+func diffTables(d *schemaDiff, from, to []domain.SnapshotTable) {
+	fromMap, toMap, keys := byKey(from, to, func(t domain.SnapshotTable) string { return t.Schema + "." + t.Name })
+
+	// Partitions are created after their parents.
+	sort.SliceStable(keys, func(i, j int) bool {
+		return toMap[keys[i]].Parent == nil && toMap[keys[j]].Parent != nil
+	})
+
+	for _, key := range keys {
+		oldTable, inFrom := fromMap[key]
+		newTable, inTo := toMap[key]
+
+		switch {
+		case !inTo:
+			d.change(actionDrop, "table", key, "")
+			for _, c := range oldTable.Constraints {
+				if c.Type == "f" {
+					d.sql(phaseDropForeignKeys, "ALTER TABLE %s DROP CONSTRAINT %s",
+						pgclient.QuoteQualified(oldTable.Schema, oldTable.Name), pgclient.QuoteIdent(c.Name))
+				}
+			}
+			// Partitions go away together with a dropped parent.
+			if oldTable.Parent != nil && oldTable.ParentSchema != nil {
+				if _, parentKept := toMap[*oldTable.ParentSchema+"."+*oldTable.Parent]; !parentKept {
+					continue
+				}
+			}
+			d.sql(phaseDropTables, "DROP TABLE %s", pgclient.QuoteQualified(oldTable.Schema, oldTable.Name))
+		case !inFrom:
+			d.change(actionCreate, "table", key, "")
+			createTable(d, newTable)
+			diffConstraints(d, newTable, nil, newTable.Constraints, false)
+			diffIndexes(d, newTable, nil, newTable.Indexes, false)
+		default:
+			if newTable.Parent == nil {
+				diffColumns(d, newTable, oldTable.Columns, newTable.Columns)
+			}
+			diffConstraints(d, newTable, oldTable.Constraints, newTable.Constraints, true)
+			diffIndexes(d, newTable, oldTable.Indexes, newTable.Indexes, true)
+		}
+	}
+}
+
+func createTable(d *schemaDiff, t domain.SnapshotTable) {
+	name := pgclient.QuoteQualified(t.Schema, t.Name)
+
+	if t.Parent != nil && t.ParentSchema != nil && t.PartitionBound != nil {
+		d.sql(phaseTables, "CREATE TABLE %s PARTITION OF %s %s",
+			name, pgclient.QuoteQualified(*t.ParentSchema, *t.Parent), *t.PartitionBound)
+		return
+	}
+
+	columns := make([]string, 0, len(t.Columns))
+	for _, c := range t.Columns {
+		columns = append(columns, "    "+columnDefinition(c))
+	}
+
+	statement := fmt.Sprintf("CREATE TABLE %s (\n%s\n)", name, strings.Join(columns, ",\n"))
+	if t.PartitionKey != nil {
+		statement += " PARTITION BY " + *t.PartitionKey
+	}
+	d.sql(phaseTables, "%s", statement)
+}
+
+func columnDefinition(c domain.SnapshotColumn) string {
+	definition := pgclient.QuoteIdent(c.Name) + " " + c.Type
+
+	switch {
+	case c.Identity != "":
+		definition += fmt.Sprintf(" GENERATED %s AS IDENTITY", strings.ToUpper(c.Identity))
+	case c.Generated && c.Default != nil:
+		definition += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", *c.Default)
+	case c.Default != nil:
+		definition += " DEFAULT " + *c.Default
+	}
+	if !c.Nullable {
+		definition += " NOT NULL"
+	}
+
+	return definition
+}
+
+func diffColumns(d *schemaDiff, t domain.SnapshotTable, from, to []domain.SnapshotColumn) {
+	table := pgclient.QuoteQualified(t.Schema, t.Name)
+	fromMap, toMap, keys := byKey(from, to, func(c domain.SnapshotColumn) string { return c.Name })
+
+	for _, key := range keys {
+		oldCol, inFrom := fromMap[key]
+		newCol, inTo := toMap[key]
+		name := t.Schema + "." + t.Name + "." + key
+		column := pgclient.QuoteIdent(key)
+
+		switch {
+		case !inTo:
+			d.change(actionDrop, "column", name, "")
+			d.sql(phaseColumns, "ALTER TABLE %s DROP COLUMN %s", table, column)
+		case !inFrom:
+			d.change(actionCreate, "column", name, newCol.Type)
+			d.sql(phaseColumns, "ALTER TABLE %s ADD COLUMN %s", table, columnDefinition(newCol))
+		default:
+			alterColumn(d, table, name, oldCol, newCol)
+		}
+	}
+}
+
+func alterColumn(d *schemaDiff, table, name string, oldCol, newCol domain.SnapshotColumn) {
+	column := pgclient.QuoteIdent(newCol.Name)
+
+	if oldCol.Type != newCol.Type {
+		d.change(actionAlter, "column", name, fmt.Sprintf("type %s -> %s", oldCol.Type, newCol.Type))
+		d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s",
+			table, column, newCol.Type, column, newCol.Type)
+	}
+
+	if oldCol.Nullable != newCol.Nullable {
+		if newCol.Nullable {
+			d.change(actionAlter, "column", name, "drop not null")
+			d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", table, column)
+		} else {
+			d.change(actionAlter, "column", name, "set not null")
+			d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", table, column)
+		}
+	}
+
+	if oldCol.Identity != newCol.Identity {
+		d.change(actionAlter, "column", name, fmt.Sprintf("identity %q -> %q", oldCol.Identity, newCol.Identity))
+		if oldCol.Identity != "" {
+			d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s DROP IDENTITY", table, column)
+		}
+		if newCol.Identity != "" {
+			d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s ADD GENERATED %s AS IDENTITY",
+				table, column, strings.ToUpper(newCol.Identity))
+		}
+	}
+
+	oldDefault, newDefault := deref(oldCol.Default), deref(newCol.Default)
+	switch {
+	case oldDefault == newDefault:
+	case oldCol.Generated || newCol.Generated:
+		d.change(actionAlter, "column", name, "generation expression changed")
+		d.note(phaseColumns, "column %s: generation expression changed, recreate the column manually", name)
+	case newCol.Identity != "":
+	case newDefault == "":
+		d.change(actionAlter, "column", name, "drop default")
+		d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", table, column)
+	case oldDefault == "":
+		d.change(actionAlter, "column", name, "set default "+newDefault)
+		d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", table, column, newDefault)
+	default:
+		d.change(actionAlter, "column", name, fmt.Sprintf("default %s -> %s", oldDefault, newDefault))
+		d.sql(phaseColumns, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", table, column, newDefault)
+	}
+}
+
+func diffConstraints(d *schemaDiff, t domain.SnapshotTable, from, to []domain.SnapshotConstraint, report bool) {
+	table := pgclient.QuoteQualified(t.Schema, t.Name)
+	fromMap, toMap, keys := byKey(from, to, func(c domain.SnapshotConstraint) string { return c.Name })
+
+	for _, key := range keys {
+		oldCon, inFrom := fromMap[key]
+		newCon, inTo := toMap[key]
+		name := t.Schema + "." + t.Name + "." + key
+
+		if inFrom && inTo && oldCon == newCon {
+			continue
+		}
+
+		if inFrom {
+			dropPhase := phaseDropConstraints
+			if oldCon.Type == "f" {
+				dropPhase = phaseDropForeignKeys
+			}
+			d.sql(dropPhase, "ALTER TABLE %s DROP CONSTRAINT %s", table, pgclient.QuoteIdent(key))
+		}
+		if inTo {
+			addPhase := phaseConstraints
+			if newCon.Type == "f" {
+				addPhase = phaseForeignKeys
+			}
+			d.sql(addPhase, "ALTER TABLE %s ADD CONSTRAINT %s %s", table, pgclient.QuoteIdent(key), newCon.Definition)
+		}
+
+		if !report {
+			continue
+		}
+		switch {
+		case !inTo:
+			d.change(actionDrop, "constraint", name, oldCon.Definition)
+		case !inFrom:
+			d.change(actionCreate, "constraint", name, newCon.Definition)
+		default:
+			d.change(actionAlter, "constraint", name, fmt.Sprintf("%s -> %s", oldCon.Definition, newCon.Definition))
+		}
+	}
+}
+
+func diffIndexes(d *schemaDiff, t domain.SnapshotTable, from, to []domain.SnapshotIndex, report bool) {
+	fromMap, toMap, keys := byKey(from, to, func(i domain.SnapshotIndex) string { return i.Name })
+
+	for _, key := range keys {
+		oldIdx, inFrom := fromMap[key]
+		newIdx, inTo := toMap[key]
+		name := t.Schema + "." + key
+
+		if inFrom && inTo && oldIdx == newIdx {
+			continue
+		}
+
+		if inFrom {
+			d.sql(phaseDropIndexes, "DROP INDEX %s", pgclient.QuoteQualified(t.Schema, key))
+		}
+		if inTo {
+			d.sql(phaseIndexes, "%s", newIdx.Definition)
+		}
+
+		if !report {
+			continue
+		}
+		switch {
+		case !inTo:
+			d.change(actionDrop, "index", name, "")
+		case !inFrom:
+			d.change(actionCreate, "index", name, newIdx.Definition)
+		default:
+			d.change(actionAlter, "index", name, newIdx.Definition)
+		}
+	}
+}
+
+func diffViews(d *schemaDiff, from, to []domain.SnapshotView) {
+	fromMap, toMap, keys := byKey(from, to, func(v domain.SnapshotView) string { return v.Schema + "." + v.Name })
+
+	for _, key := range keys {
+		oldView, inFrom := fromMap[key]
+		newView, inTo := toMap[key]
+
+		if inFrom && inTo && oldView == newView {
+			continue
+		}
+
+		if inFrom {
+			d.sql(phaseDropViews, "DROP %s %s", viewKind(oldView), pgclient.QuoteQualified(oldView.Schema, oldView.Name))
+		}
+		if inTo {
+			d.sql(phaseViews, "CREATE %s %s AS\n%s",
+				viewKind(newView), pgclient.QuoteQualified(newView.Schema, newView.Name), trimStatement(newView.Definition))
+		}
+
+		kind := strings.ToLower(viewKind(newView))
+		switch {
+		case !inTo:
+			d.change(actionDrop, strings.ToLower(viewKind(oldView)), key, "")
+		case !inFrom:
+			d.change(actionCreate, kind, key, "")
+		default:
+			d.change(actionAlter, kind, key, "definition changed")
+		}
+	}
+}
+
+func viewKind(v domain.SnapshotView) string {
+	if v.Materialized {
+		return "MATERIALIZED VIEW"
+	}
+
+	return "VIEW"
+}
+
+func diffTriggers(d *schemaDiff, from, to []domain.SnapshotTrigger) {
+	fromMap, toMap, keys := byKey(from, to, func(t domain.SnapshotTrigger) string {
+		return t.Schema + "." + t.Table + "." + t.Name
+	})
+
+	for _, key := range keys {
+		oldTrigger, inFrom := fromMap[key]
+		newTrigger, inTo := toMap[key]
+
+		if inFrom && inTo && oldTrigger == newTrigger {
+			continue
+		}
+
+		if inFrom {
+			d.sql(phaseDropTriggers, "DROP TRIGGER %s ON %s",
+				pgclient.QuoteIdent(oldTrigger.Name), pgclient.QuoteQualified(oldTrigger.Schema, oldTrigger.Table))
+		}
+		if inTo {
+			d.sql(phaseTriggers, "%s", newTrigger.Definition)
+		}
+
+		switch {
+		case !inTo:
+			d.change(actionDrop, "trigger", key, "")
+		case !inFrom:
+			d.change(actionCreate, "trigger", key, "")
+		default:
+			d.change(actionAlter, "trigger", key, "definition changed")
+		}
+	}
+}
+
+func quoteLiterals(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, pgclient.QuoteLiteral(v))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func trimStatement(statement string) string {
+	return strings.TrimRight(strings.TrimSpace(statement), ";")
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"l6/internal/domain"
+	"strings"
+	"testing"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestDiffSnapshots(t *testing.T) {
+	serialTable := domain.SnapshotTable{
+		Schema: "public",
+		Name:   "orders",
+		Columns: []domain.SnapshotColumn{
+			{Name: "id", Type: "integer", Default: ptr("nextval('public.orders_id_seq'::regclass)"), Position: 1},
+		},
+	}
+	serialSequence := domain.SnapshotSequence{
+		Schema: "public", Name: "orders_id_seq", DataType: "integer", Start: 1, Increment: 1, Min: 1, Max: 2147483647,
+	}
+	countFunction := domain.SnapshotFunction{
+		Schema:     "public",
+		Name:       "order_count",
+		Kind:       "function",
+		Definition: "CREATE OR REPLACE FUNCTION public.order_count()\n RETURNS bigint\n LANGUAGE sql\nAS $function$SELECT count(*) FROM public.orders$function$\n",
+	}
+
+	tests := []struct {
+		name    string
+		from    domain.SchemaSnapshot
+		to      domain.SchemaSnapshot
+		changes int
+		ordered []string
+		absent  []string
+	}{
+		{
+			name:    "identical",
+			from:    domain.SchemaSnapshot{Tables: []domain.SnapshotTable{serialTable}},
+			to:      domain.SchemaSnapshot{Tables: []domain.SnapshotTable{serialTable}},
+			ordered: []string{"-- Schemas are identical"},
+			absent:  []string{"BEGIN;"},
+		},
+		{
+			name:    "dropped table with serial column",
+			from:    domain.SchemaSnapshot{Tables: []domain.SnapshotTable{serialTable}, Sequences: []domain.SnapshotSequence{serialSequence}},
+			to:      domain.SchemaSnapshot{},
+			changes: 2,
+			ordered: []string{`DROP TABLE "public"."orders";`, `DROP SEQUENCE IF EXISTS "public"."orders_id_seq";`},
+		},
+		{
+			name:    "created table with serial column",
+			from:    domain.SchemaSnapshot{},
+			to:      domain.SchemaSnapshot{Tables: []domain.SnapshotTable{serialTable}, Sequences: []domain.SnapshotSequence{serialSequence}},
+			changes: 2,
+			ordered: []string{`CREATE SEQUENCE "public"."orders_id_seq"`, `CREATE TABLE "public"."orders"`},
+		},
+		{
+			name:    "created SQL function reading a created table",
+			from:    domain.SchemaSnapshot{},
+			to:      domain.SchemaSnapshot{Tables: []domain.SnapshotTable{serialTable}, Functions: []domain.SnapshotFunction{countFunction}},
+			changes: 2,
+			ordered: []string{`CREATE TABLE "public"."orders"`, "CREATE OR REPLACE FUNCTION public.order_count()"},
+		},
+		{
+			name:    "dropped function and table",
+			from:    domain.SchemaSnapshot{Tables: []domain.SnapshotTable{serialTable}, Functions: []domain.SnapshotFunction{countFunction}},
+			to:      domain.SchemaSnapshot{},
+			changes: 2,
+			ordered: []string{`DROP TABLE "public"."orders";`, `DROP FUNCTION "public"."order_count"();`},
+		},
+		{
+			name: "dropped partitioned table",
+			from: domain.SchemaSnapshot{Tables: []domain.SnapshotTable{
+				{Schema: "public", Name: "events", PartitionKey: ptr("RANGE (at)")},
+				{Schema: "public", Name: "events_2024", ParentSchema: ptr("public"), Parent: ptr("events"), PartitionBound: ptr("FOR VALUES FROM ('2024-01-01') TO ('2025-01-01')")},
+			}},
+			to:      domain.SchemaSnapshot{},
+			changes: 2,
+			ordered: []string{`DROP TABLE "public"."events";`},
+			absent:  []string{`DROP TABLE "public"."events_2024"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := diffSnapshots(tt.from, tt.to)
+
+			if len(diff.Changes) != tt.changes {
+				t.Errorf("got %d changes, want %d: %+v", len(diff.Changes), tt.changes, diff.Changes)
+			}
+			rest := diff.Migration
+			for _, want := range tt.ordered {
+				i := strings.Index(rest, want)
+				if i < 0 {
+					t.Fatalf("migration lacks %q after the previous statements:\n%s", want, diff.Migration)
+				}
+				rest = rest[i+len(want):]
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(diff.Migration, unwanted) {
+					t.Errorf("migration contains %q:\n%s", unwanted, diff.Migration)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+)
+
+type SequenceRepository interface {
+	Sequences(ctx context.Context, schemas []string, table string) ([]domain.Sequence, error)
+	ResyncSequences(ctx context.Context, schemas []string, table string) ([]domain.SequenceResynced, error)
+}
+
+func (s *Service) Sequences(ctx context.Context, schemas []string, table string) ([]domain.Sequence, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	sequences, err := conn.repo.Sequences(ctx, schemas, table)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return sequences, nil
+}
+
+// ResyncSequences moves the sequences owned by table, or by every table of the
+// schemas when table is empty, past the largest value used in their columns.
+func (s *Service) ResyncSequences(ctx context.Context, schemas []string, table string) ([]domain.SequenceResynced, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	resynced, err := conn.repo.ResyncSequences(ctx, schemas, table)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return resynced, nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"strings"
+)
+
+type SettingsRepository interface {
+	Settings(ctx context.Context, name string) ([]domain.Setting, error)
+	PendingSettings(ctx context.Context) ([]domain.PendingSetting, error)
+	AlterSystem(ctx context.Context, name string, value *string) error
+}
+
+// Settings returns the server parameters grouped by category, optionally of the
+// categories starting with category, compared case-insensitively.
+func (s *Service) Settings(ctx context.Context, category string) ([]domain.SettingCategory, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	settings, err := conn.repo.Settings(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+
+	categories := []domain.SettingCategory{}
+	for _, setting := range settings {
+		if !strings.HasPrefix(strings.ToLower(setting.Category), strings.ToLower(category)) {
+			continue
+		}
+		if n := len(categories); n == 0 || categories[n-1].Name != setting.Category {
+			categories = append(categories, domain.SettingCategory{Name: setting.Category})
+		}
+		last := &categories[len(categories)-1]
+		last.Settings = append(last.Settings, setting)
+	}
+
+	return categories, nil
+}
+
+// PendingSettings returns the configuration file values not applied to the running server.
+func (s *Service) PendingSettings(ctx context.Context) ([]domain.PendingSetting, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	pending, err := conn.repo.PendingSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("repo: %w", err)
+	}
+	return pending, nil
+}
+
+func (s *Service) SetSetting(ctx context.Context, name string, change domain.SettingChange) (domain.SettingChanged, error) {
+	return s.alterSystem(ctx, name, &change.Value)
+}
+
+func (s *Service) ResetSetting(ctx context.Context, name string) (domain.SettingChanged, error) {
+	return s.alterSystem(ctx, name, nil)
+}
+
+// alterSystem changes a parameter with ALTER SYSTEM and reloads the configuration.
+// The reload is asynchronous, so the returned setting may still show the old value.
+func (s *Service) alterSystem(ctx context.Context, name string, value *string) (domain.SettingChanged, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.SettingChanged{}, err
+	}
+	defer conn.release()
+
+	setting, err := s.setting(ctx, conn, name)
+	if err != nil {
+		return domain.SettingChanged{}, err
+	}
+	if setting.Context == "internal" {
+		return domain.SettingChanged{}, fmt.Errorf("%w: %s is read-only", domain.ErrInvalidRequest, name)
+	}
+
+	if err = conn.repo.AlterSystem(ctx, name, value); err != nil {
+		return domain.SettingChanged{}, fmt.Errorf("repo: %w", err)
+	}
+
+	if setting, err = s.setting(ctx, conn, name); err != nil {
+		return domain.SettingChanged{}, err
+	}
+	changed := domain.SettingChanged{Setting: setting}
+	switch setting.Context {
+	case "postmaster":
+		changed.Warning = fmt.Sprintf("%s requires a server restart to take effect", name)
+	case "backend", "superuser-backend":
+		changed.Warning = fmt.Sprintf("%s only applies to new sessions", name)
+	}
+
+	return changed, nil
+}
+
+func (s *Service) setting(ctx context.Context, conn *connection, name string) (domain.Setting, error) {
+	settings, err := conn.repo.Settings(ctx, name)
+	if err != nil {
+		return domain.Setting{}, fmt.Errorf("repo: %w", err)
+	}
+	if len(settings) == 0 {
+		return domain.Setting{}, fmt.Errorf("%w: setting %s", domain.ErrNotFound, name)
+	}
+	return settings[0], nil
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"l6/internal/domain"
+	"log/slog"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+type StatementsRepository interface {
+	StatementsSchema(ctx context.Context) (string, error)
+	Statements(ctx context.Context, schema string) ([]domain.QueryStat, error)
+	ResetStatements(ctx context.Context, schema string) error
+}
+
+const (
+	defaultStatementsInterval  = 5 * time.Minute
+	defaultStatementsRetention = 24 * time.Hour
+	defaultStatementsLimit     = 20
+	defaultStatementsKept      = 1000
+)
+
+var errStatementsMissing = fmt.Errorf("%w: pg_stat_statements is not installed", domain.ErrInvalidRequest)
+
+// statementSorts orders statements by the named figure, largest first. io is the
+// number of shared blocks read from disk or written.
+var statementSorts = map[string]func(domain.QueryStat) float64{
+	"total_time": func(q domain.QueryStat) float64 { return q.TotalTimeMs },
+	"mean_time":  func(q domain.QueryStat) float64 { return q.MeanTimeMs },
+	"calls":      func(q domain.QueryStat) float64 { return float64(q.Calls) },
+	"rows":       func(q domain.QueryStat) float64 { return float64(q.Rows) },
+	"io":         func(q domain.QueryStat) float64 { return float64(q.SharedBlksRead + q.SharedBlksWritten) },
+}
+
+// statementKey identifies a row of pg_stat_statements in the current database.
+type statementKey struct {
+	queryID  int64
+	user     string
+	topLevel bool
+}
+
+func keyOf(q domain.QueryStat) statementKey {
+	return statementKey{queryID: q.QueryID, user: q.User, topLevel: q.TopLevel}
+}
+
+// statementCounters are the cumulative figures of a statement. Query texts are not
+// kept, reports take them from the current rows.
+type statementCounters struct {
+	calls             int64
+	totalTimeMs       float64
+	rows              int64
+	sharedBlksHit     int64
+	sharedBlksRead    int64
+	sharedBlksDirtied int64
+	sharedBlksWritten int64
+}
+
+type statementsSnapshot struct {
+	domain.StatementsSnapshot
+	counters map[statementKey]statementCounters
+}
+
+// newStatementsSnapshot keeps the counters of at most limit statements, those
+// with the largest total time.
+func newStatementsSnapshot(takenAt time.Time, stats []domain.QueryStat, limit int) statementsSnapshot {
+	if len(stats) > limit {
+		stats = slices.Clone(stats)
+		sort.Slice(stats, func(i, j int) bool { return stats[i].TotalTimeMs > stats[j].TotalTimeMs })
+	}
+
+	snapshot := statementsSnapshot{
+		StatementsSnapshot: domain.StatementsSnapshot{
+			TakenAt:   takenAt,
+			Queries:   min(len(stats), limit),
+			Truncated: len(stats) > limit,
+		},
+		counters: make(map[statementKey]statementCounters, min(len(stats), limit)),
+	}
+	for _, q := range stats[:snapshot.Queries] {
+		snapshot.counters[keyOf(q)] = statementCounters{
+			calls:             q.Calls,
+			totalTimeMs:       q.TotalTimeMs,
+			rows:              q.Rows,
+			sharedBlksHit:     q.SharedBlksHit,
+			sharedBlksRead:    q.SharedBlksRead,
+			sharedBlksDirtied: q.SharedBlksDirtied,
+			sharedBlksWritten: q.SharedBlksWritten,
+		}
+	}
+
+	return snapshot
+}
+
+// statementSnapshots keeps the pg_stat_statements snapshots of every connection, oldest first.
+type statementSnapshots struct {
+	mu        sync.Mutex
+	snapshots map[string][]statementsSnapshot
+}
+
+func (s *statementSnapshots) add(id string, snapshot statementsSnapshot, retention time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := snapshot.TakenAt.Add(-retention)
+	kept := slices.DeleteFunc(s.snapshots[id], func(old statementsSnapshot) bool {
+		return old.TakenAt.Before(cutoff)
+	})
+	s.snapshots[id] = append(kept, snapshot)
+}
+
+func (s *statementSnapshots) list(id string) []statementsSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.snapshots[id])
+}
+
+func (s *statementSnapshots) clear(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.snapshots, id)
+}
+
+// RunStatementSnapshots snapshots pg_stat_statements of every open connection at the
+// configured interval until ctx is done. Profiles without the extension are logged
+// once and skipped afterwards; an updated profile is checked again.
+func (s *Service) RunStatementSnapshots(ctx context.Context, l *slog.Logger) {
+	interval := s.cfg.Statements.SnapshotInterval
+	if interval <= 0 {
+		interval = defaultStatementsInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	skipped := make(map[*connection]bool)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			missing := make(map[*connection]bool)
+			for _, conn := range s.connections.connected() {
+				if skipped[conn] {
+					missing[conn] = true
+					conn.release()
+					continue
+				}
+				_, err := s.snapshotStatements(ctx, conn)
+				switch {
+				case errors.Is(err, errStatementsMissing):
+					missing[conn] = true
+					l.Info("pg_stat_statements is not installed, skipping snapshots", "connection", conn.cfg.ID)
+				case err != nil:
+					l.Warn("Failed to snapshot pg_stat_statements", "connection", conn.cfg.ID, "error", err)
+				}
+				conn.release()
+			}
+			skipped = missing
+		}
+	}
+}
+
+// TakeStatementsSnapshot snapshots pg_stat_statements of the current connection now.
+func (s *Service) TakeStatementsSnapshot(ctx context.Context) (domain.StatementsSnapshot, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.StatementsSnapshot{}, err
+	}
+	defer conn.release()
+	return s.snapshotStatements(ctx, conn)
+}
+
+func (s *Service) StatementsSnapshots(ctx context.Context) ([]domain.StatementsSnapshot, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.release()
+	snapshots := []domain.StatementsSnapshot{}
+	for _, snapshot := range s.statements.list(conn.cfg.ID) {
+		snapshots = append(snapshots, snapshot.StatementsSnapshot)
+	}
+	return snapshots, nil
+}
+
+// TopStatements returns the top statements by sort. With a positive window the
+// figures are deltas against the latest snapshot taken at least window ago, or the
+// oldest one kept when none is that old.
+func (s *Service) TopStatements(ctx context.Context, sortBy string, limit int, window time.Duration) (domain.StatementsReport, error) {
+	if sortBy == "" {
+		sortBy = "total_time"
+	}
+	key, ok := statementSorts[sortBy]
+	if !ok {
+		return domain.StatementsReport{}, fmt.Errorf("%w: sort must be total_time, mean_time, calls, rows or io", domain.ErrInvalidRequest)
+	}
+	if limit <= 0 {
+		limit = defaultStatementsLimit
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.StatementsReport{}, err
+	}
+	defer conn.release()
+	schema, err := conn.repo.StatementsSchema(ctx)
+	if err != nil {
+		return domain.StatementsReport{}, fmt.Errorf("repo: %w", err)
+	}
+
+	report := domain.StatementsReport{Sort: sortBy, To: time.Now(), Statements: []domain.QueryStat{}}
+	if schema == "" {
+		return report, nil
+	}
+	report.Installed = true
+
+	stats, err := conn.repo.Statements(ctx, schema)
+	if err != nil {
+		return domain.StatementsReport{}, fmt.Errorf("repo: %w", err)
+	}
+
+	if window > 0 {
+		if base, ok := baseSnapshot(s.statements.list(conn.cfg.ID), report.To.Add(-window)); ok {
+			stats = statementDeltas(base.counters, stats)
+			report.From = &base.TakenAt
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return key(stats[i]) > key(stats[j])
+	})
+	if len(stats) > limit {
+		stats = stats[:limit]
+	}
+	report.Statements = stats
+
+	return report, nil
+}
+
+// ResetStatements resets pg_stat_statements and drops the snapshots of the
+// connection, whose deltas would no longer be meaningful.
+func (s *Service) ResetStatements(ctx context.Context) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	schema, err := conn.repo.StatementsSchema(ctx)
+	if err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	if schema == "" {
+		return errStatementsMissing
+	}
+
+	if err = conn.repo.ResetStatements(ctx, schema); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	s.statements.clear(conn.cfg.ID)
+
+	return nil
+}
+
+func (s *Service) snapshotStatements(ctx context.Context, conn *connection) (domain.StatementsSnapshot, error) {
+	schema, err := conn.repo.StatementsSchema(ctx)
+	if err != nil {
+		return domain.StatementsSnapshot{}, fmt.Errorf("repo: %w", err)
+	}
+	if schema == "" {
+		return domain.StatementsSnapshot{}, errStatementsMissing
+	}
+
+	stats, err := conn.repo.Statements(ctx, schema)
+	if err != nil {
+		return domain.StatementsSnapshot{}, fmt.Errorf("repo: %w", err)
+	}
+
+	retention := s.cfg.Statements.Retention
+	if retention <= 0 {
+		retention = defaultStatementsRetention
+	}
+	limit := s.cfg.Statements.MaxStatements
+	if limit <= 0 {
+		limit = defaultStatementsKept
+	}
+	snapshot := newStatementsSnapshot(time.Now(), stats, limit)
+	s.statements.add(conn.cfg.ID, snapshot, retention)
+
+	return snapshot.StatementsSnapshot, nil
+}
+
+func baseSnapshot(snapshots []statementsSnapshot, at time.Time) (statementsSnapshot, bool) {
+	if len(snapshots) == 0 {
+		return statementsSnapshot{}, false
+	}
+
+	base := snapshots[0]
+	for _, snapshot := range snapshots {
+		if snapshot.TakenAt.After(at) {
+			break
+		}
+		base = snapshot
+	}
+
+	return base, true
+}
+
+// statementDeltas subtracts the base figures from the current ones. Statements
+// whose counters went backwards were evicted or reset and keep their current
+// figures, as do statements missing from a truncated base.
+func statementDeltas(base map[statementKey]statementCounters, current []domain.QueryStat) []domain.QueryStat {
+	deltas := make([]domain.QueryStat, 0, len(current))
+	for _, q := range current {
+		if old, ok := base[keyOf(q)]; ok && q.Calls >= old.calls {
+			q.Calls -= old.calls
+			q.TotalTimeMs -= old.totalTimeMs
+			q.Rows -= old.rows
+			q.SharedBlksHit -= old.sharedBlksHit
+			q.SharedBlksRead -= old.sharedBlksRead
+			q.SharedBlksDirtied -= old.sharedBlksDirtied
+			q.SharedBlksWritten -= old.sharedBlksWritten
+		}
+		if q.Calls == 0 {
+			continue
+		}
+		q.MeanTimeMs = q.TotalTimeMs / float64(q.Calls)
+		deltas = append(deltas, q)
+	}
+
+	return deltas
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+)
+
+type TableStatsRepository interface {
+	TableStats(ctx context.Context, schemas []string, table string) ([]domain.TableStats, error)
+	VacuumTable(ctx context.Context, schema, table string, opts domain.VacuumOptions) error
+	AnalyzeTable(ctx context.Context, schema, table string) error
+}
+
+// TableStatsReport returns the statistics of all tables of the given schemas with totals.
+func (s *Service) TableStatsReport(ctx context.Context, schemas []string) (domain.TableStatsReport, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.TableStatsReport{}, err
+	}
+	defer conn.release()
+	stats, err := conn.repo.TableStats(ctx, schemas, "")
+	if err != nil {
+		return domain.TableStatsReport{}, fmt.Errorf("repo: %w", err)
+	}
+
+	report := domain.TableStatsReport{Tables: stats}
+	for _, table := range stats {
+		report.TotalBytes += table.TotalBytes
+		report.IndexesBytes += table.IndexesBytes
+		report.ToastBytes += table.ToastBytes
+		report.LiveTuples += table.LiveTuples
+		report.DeadTuples += table.DeadTuples
+		if table.BloatBytes != nil {
+			report.BloatBytes += *table.BloatBytes
+		}
+	}
+
+	return report, nil
+}
+
+func (s *Service) TableStats(ctx context.Context, schema, table string) (domain.TableStats, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.TableStats{}, err
+	}
+	defer conn.release()
+	schema = schemaOrDefault(schema)
+
+	stats, err := conn.repo.TableStats(ctx, []string{schema}, table)
+	if err != nil {
+		return domain.TableStats{}, fmt.Errorf("repo: %w", err)
+	}
+	if len(stats) == 0 {
+		return domain.TableStats{}, fmt.Errorf("%w: table %s.%s", domain.ErrNotFound, schema, table)
+	}
+	return stats[0], nil
+}
+
+// VacuumTable queues a VACUUM of the table. VACUUM FULL holds an ACCESS EXCLUSIVE
+// lock for as long as it rewrites the table, so vacuums run as background jobs that
+// can be cancelled.
+func (s *Service) VacuumTable(ctx context.Context, schema, table string, opts domain.VacuumOptions) (domain.Job, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	defer conn.release()
+
+	schema = schemaOrDefault(schema)
+	spec := domain.Job{Kind: domain.JobVacuum, Object: schema + "." + table}
+	return s.startJob(conn, spec, func(ctx context.Context, _ *domain.ToolProgress) (string, any, error) {
+		if err := conn.repo.VacuumTable(ctx, schema, table, opts); err != nil {
+			return "", nil, fmt.Errorf("repo: %w", err)
+		}
+		return "", nil, nil
+	})
+}
+
+func (s *Service) AnalyzeTable(ctx context.Context, schema, table string) error {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.AnalyzeTable(ctx, schemaOrDefault(schema), table); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+)
+
+type TypeRepository interface {
+	Types(ctx context.Context, schemas []string) (domain.Types, error)
+	CreateEnum(ctx context.Context, enum domain.EnumCreate) error
+	AddEnumValue(ctx context.Context, schema, name string, value domain.EnumValueAdd) error
+	RenameEnumValue(ctx context.Context, schema, name, from, to string) error
+	CreateDomain(ctx context.Context, create domain.DomainCreate) error
+}
+
+func (s *Service) Types(ctx context.Context, schemas []string) (domain.Types, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.Types{}, err
+	}
+	defer conn.release()
+	types, err := conn.repo.Types(ctx, schemas)
+	if err != nil {
+		return domain.Types{}, fmt.Errorf("repo: %w", err)
+	}
+	return types, nil
+}
+
+func (s *Service) CreateEnum(ctx context.Context, enum domain.EnumCreate) error {
+	if enum.Name == "" {
+		return fmt.Errorf("%w: enum name is required", domain.ErrInvalidRequest)
+	}
+	for i, label := range enum.Labels {
+		if label == "" {
+			return fmt.Errorf("%w: enum labels cannot be empty", domain.ErrInvalidRequest)
+		}
+		if slices.Contains(enum.Labels[:i], label) {
+			return fmt.Errorf("%w: duplicate enum label %q", domain.ErrInvalidRequest, label)
+		}
+	}
+	enum.Schema = schemaOrDefault(enum.Schema)
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.CreateEnum(ctx, enum); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) AddEnumValue(ctx context.Context, schema, name string, value domain.EnumValueAdd) error {
+	if value.Value == "" {
+		return fmt.Errorf("%w: enum value is required", domain.ErrInvalidRequest)
+	}
+	if value.Before != "" && value.After != "" {
+		return fmt.Errorf("%w: before and after are mutually exclusive", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.AddEnumValue(ctx, schemaOrDefault(schema), name, value); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) RenameEnumValue(ctx context.Context, schema, name, from, to string) error {
+	if from == "" || to == "" {
+		return fmt.Errorf("%w: enum value rename needs both from and to", domain.ErrInvalidRequest)
+	}
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.RenameEnumValue(ctx, schemaOrDefault(schema), name, from, to); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) CreateDomain(ctx context.Context, create domain.DomainCreate) error {
+	if create.Name == "" {
+		return fmt.Errorf("%w: domain name is required", domain.ErrInvalidRequest)
+	}
+	if err := validateType(create.Name, create.BaseType); err != nil {
+		return err
+	}
+	if create.Default != nil {
+		if err := validateExpression("DEFAULT", *create.Default); err != nil {
+			return err
+		}
+	}
+	for _, check := range create.Checks {
+		if check.Expression == "" {
+			return fmt.Errorf("%w: check constraint needs an expression", domain.ErrInvalidRequest)
+		}
+		if err := validateExpression("CHECK", check.Expression); err != nil {
+			return err
+		}
+	}
+	create.Schema = schemaOrDefault(create.Schema)
+
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.release()
+	if err = conn.repo.CreateDomain(ctx, create); err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	return nil
+}
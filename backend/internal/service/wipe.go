@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"slices"
+)
+
+type WipeRepository interface {
+	WipePreview(ctx context.Context) (domain.WipePreview, error)
+	DeleteAllTables(ctx context.Context, opts domain.WipeOptions) (domain.WipeReport, error)
+}
+
+// PreviewDeleteAllTables reports what DeleteAllTables would remove and issues
+// a short-lived token that has to be passed to it.
+func (s *Service) PreviewDeleteAllTables(ctx context.Context) (domain.WipePreview, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.WipePreview{}, err
+	}
+	defer conn.release()
+	preview, err := conn.repo.WipePreview(ctx)
+	if err != nil {
+		return domain.WipePreview{}, fmt.Errorf("repo: %w", err)
+	}
+
+	preview.ExpiresAt = s.confirmExpiry()
+	preview.Token = s.confirms.issue(confirmToken{
+		action:     confirmWipe,
+		connection: conn.cfg.ID,
+		targets:    wipeTableNames(preview),
+		expiresAt:  preview.ExpiresAt,
+	})
+
+	return preview, nil
+}
+
+// DeleteAllTables checks the confirmation token and that the set of tables has
+// not changed since the preview, then queues a job that takes a backup and only
+// then drops the tables along with the object kinds selected in opts. The
+// TablesDeleted report is the result of the job.
+func (s *Service) DeleteAllTables(ctx context.Context, token string, opts domain.WipeOptions) (domain.Job, error) {
+	conn, err := s.conn(ctx)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	defer conn.release()
+
+	issued, ok := s.confirms.take(token, confirmWipe, conn.cfg.ID)
+	if !ok {
+		return domain.Job{}, fmt.Errorf("%w: confirmation token is invalid or expired, request a new preview", domain.ErrInvalidRequest)
+	}
+	if err = checkWipeTables(ctx, conn, issued); err != nil {
+		return domain.Job{}, err
+	}
+
+	return s.startJob(conn, domain.Job{Kind: domain.JobWipe}, func(ctx context.Context, progress *domain.ToolProgress) (string, any, error) {
+		backup, err := conn.repo.CreateBackup(ctx, conn.cfg.BackupDir, domain.BackupOptions{}, progress)
+		if err != nil {
+			return "", nil, fmt.Errorf("pre-wipe backup: %w", err)
+		}
+		// The job may have waited in the queue, the backup has to cover what is dropped.
+		if err = checkWipeTables(ctx, conn, issued); err != nil {
+			return backup.Filename, nil, err
+		}
+
+		removed, err := conn.repo.DeleteAllTables(ctx, opts)
+		if err != nil {
+			return backup.Filename, nil, fmt.Errorf("failed to delete all tables: %w", err)
+		}
+
+		return backup.Filename, domain.TablesDeleted{
+			Backup:  backup.Filename,
+			Removed: removed,
+			Message: "All tables deleted successfully",
+			Success: true,
+		}, nil
+	})
+}
+
+func checkWipeTables(ctx context.Context, conn *connection, issued confirmToken) error {
+	preview, err := conn.repo.WipePreview(ctx)
+	if err != nil {
+		return fmt.Errorf("repo: %w", err)
+	}
+	if !slices.Equal(issued.targets, wipeTableNames(preview)) {
+		return fmt.Errorf("%w: tables changed since the preview, request a new preview", domain.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func wipeTableNames(preview domain.WipePreview) []string {
+	names := make([]string, 0, len(preview.Tables))
+	for _, table := range preview.Tables {
+		names = append(names, table.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ActivityService interface {
+	Activity(ctx context.Context, all bool) ([]domain.Session, error)
+	Locks(ctx context.Context) (domain.LockTree, error)
+	CancelBackend(ctx context.Context, pid int) (domain.SessionSignaled, error)
+	TerminateBackend(ctx context.Context, pid int) (domain.SessionSignaled, error)
+}
+
+// @Summary Get activity
+// @Description Returns the sessions from pg_stat_activity with user, application, client, state, wait event, query, query duration and transaction age
+// @Tags activity
+// @Accept json
+// @Produce json
+// @Param all query bool false "Include background processes"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Session
+// @Failure 500 {object} ErrorResponse
+// @Router /activity [get]
+func (h *Handler) Activity(c *gin.Context) {
+	h.logger.Info("Activity request received")
+	sessions, err := h.service.Activity(c, c.Query("all") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get activity", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// @Summary Get locks
+// @Description Returns the blocking tree built from pg_locks and pg_blocking_pids: blocking sessions with the sessions waiting on them and the lock they wait for
+// @Tags activity
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.LockTree
+// @Failure 500 {object} ErrorResponse
+// @Router /locks [get]
+func (h *Handler) Locks(c *gin.Context) {
+	h.logger.Info("Locks request received")
+	tree, err := h.service.Locks(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get locks", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, tree)
+}
+
+// @Summary Cancel query
+// @Description Cancels the running query of a session with pg_cancel_backend
+// @Tags activity
+// @Accept json
+// @Produce json
+// @Param pid path int true "Backend pid"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.SessionSignaled
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /activity/{pid}/cancel [post]
+func (h *Handler) CancelBackend(c *gin.Context) {
+	h.logger.Info("CancelBackend request received")
+	pid, err := strconv.Atoi(c.Param("pid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid pid"})
+		return
+	}
+	result, err := h.service.CancelBackend(c, pid)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to cancel query", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+	h.logger.Info("Query cancelled", "pid", pid)
+}
+
+// @Summary Terminate session
+// @Description Terminates a session with pg_terminate_backend, rolling back its open transaction
+// @Tags activity
+// @Accept json
+// @Produce json
+// @Param pid path int true "Backend pid"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.SessionSignaled
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /activity/{pid}/terminate [post]
+func (h *Handler) TerminateBackend(c *gin.Context) {
+	h.logger.Info("TerminateBackend request received")
+	pid, err := strconv.Atoi(c.Param("pid"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid pid"})
+		return
+	}
+	result, err := h.service.TerminateBackend(c, pid)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to terminate session", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+	h.logger.Info("Session terminated", "pid", pid)
+}
@@ -0,0 +1,200 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/config"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// connectionHeader is an alternative to the connection query parameter.
+const connectionHeader = "X-Connection-ID"
+
+type ConnectionService interface {
+	ListConnections(ctx context.Context) ([]domain.Connection, error)
+	Connection(ctx context.Context, id string) (domain.Connection, error)
+	AddConnection(ctx context.Context, profile config.ConnectionConfig) (domain.Connection, error)
+	UpdateConnection(ctx context.Context, id string, profile config.ConnectionConfig) (domain.Connection, error)
+	RemoveConnection(ctx context.Context, id string) error
+	TestConnection(ctx context.Context, id string) (domain.ConnectionTested, error)
+}
+
+// ConnectionRequest describes a connection profile to add or update
+type ConnectionRequest struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Host               string `json:"host"`
+	Port               string `json:"port"`
+	Database           string `json:"database"`
+	Username           string `json:"username"`
+	Password           string `json:"password"`
+	BackupDir          string `json:"backup_dir"`
+	ConnectionAttempts int    `json:"connection_attempts"`
+	MaxOpenConns       int    `json:"max_open_conns"`
+	MaxIdleConns       int    `json:"max_idle_conns"`
+}
+
+func (r ConnectionRequest) profile() config.ConnectionConfig {
+	return config.ConnectionConfig{
+		ID:        r.ID,
+		Name:      r.Name,
+		BackupDir: r.BackupDir,
+		Postgres: config.PostgresConfig{
+			Host:               r.Host,
+			Port:               r.Port,
+			Database:           r.Database,
+			Username:           r.Username,
+			Password:           r.Password,
+			ConnectionAttempts: r.ConnectionAttempts,
+			MaxOpenConns:       r.MaxOpenConns,
+			MaxIdleConns:       r.MaxIdleConns,
+		},
+	}
+}
+
+// connectionMiddleware selects the connection profile from the connection query
+// parameter or the X-Connection-ID header. Requests naming neither use the default profile.
+func (h *Handler) connectionMiddleware(c *gin.Context) {
+	id := c.Query("connection")
+	if id == "" {
+		id = c.GetHeader(connectionHeader)
+	}
+	if id == "" {
+		c.Next()
+		return
+	}
+
+	if _, err := h.service.Connection(c, id); err != nil {
+		c.AbortWithStatusJSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.Request = c.Request.WithContext(domain.ContextWithConnection(c.Request.Context(), id))
+	c.Next()
+}
+
+// @Summary Get list of connections
+// @Description Returns all registered connection profiles
+// @Tags connections
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string][]domain.Connection
+// @Failure 500 {object} ErrorResponse
+// @Router /connections [get]
+func (h *Handler) ListConnections(c *gin.Context) {
+	h.logger.Info("ListConnections request received")
+	connections, err := h.service.ListConnections(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list connections", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"connections": connections})
+}
+
+// @Summary Add connection
+// @Description Registers a new connection profile after checking that the database is reachable
+// @Tags connections
+// @Accept json
+// @Produce json
+// @Param request body ConnectionRequest true "Connection profile"
+// @Success 201 {object} domain.Connection
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /connections [post]
+func (h *Handler) AddConnection(c *gin.Context) {
+	h.logger.Info("AddConnection request received")
+	var request ConnectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	connection, err := h.service.AddConnection(c, request.profile())
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to add connection", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, connection)
+	h.logger.Info("Connection added successfully", "id", connection.ID)
+}
+
+// @Summary Update connection
+// @Description Replaces a connection profile and reconnects it. An empty password keeps the current one
+// @Tags connections
+// @Accept json
+// @Produce json
+// @Param id path string true "Connection ID"
+// @Param request body ConnectionRequest true "Connection profile"
+// @Success 200 {object} domain.Connection
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /connections/{id} [put]
+func (h *Handler) UpdateConnection(c *gin.Context) {
+	h.logger.Info("UpdateConnection request received")
+	id := c.Param("id")
+	var request ConnectionRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	connection, err := h.service.UpdateConnection(c, id, request.profile())
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to update connection", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, connection)
+	h.logger.Info("Connection updated successfully", "id", id)
+}
+
+// @Summary Test connection
+// @Description Connects to the profile if needed and pings the database
+// @Tags connections
+// @Accept json
+// @Produce json
+// @Param id path string true "Connection ID"
+// @Success 200 {object} domain.ConnectionTested
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /connections/{id}/test [post]
+func (h *Handler) TestConnection(c *gin.Context) {
+	h.logger.Info("TestConnection request received")
+	id := c.Param("id")
+	result, err := h.service.TestConnection(c, id)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to test connection", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// @Summary Remove connection
+// @Description Closes the pool of a connection profile and removes it from the registry
+// @Tags connections
+// @Accept json
+// @Produce json
+// @Param id path string true "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /connections/{id} [delete]
+func (h *Handler) RemoveConnection(c *gin.Context) {
+	h.logger.Info("RemoveConnection request received")
+	id := c.Param("id")
+	err := h.service.RemoveConnection(c, id)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to remove connection", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Connection removed successfully"})
+	h.logger.Info("Connection removed successfully", "id", id)
+}
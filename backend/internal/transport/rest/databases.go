@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DatabaseService interface {
+	Databases(ctx context.Context) ([]domain.Database, error)
+	CreateDatabase(ctx context.Context, database domain.DatabaseCreate) error
+	RenameDatabase(ctx context.Context, name string, newName string) error
+	PreviewDropDatabase(ctx context.Context, name string) (domain.DatabaseDropPreview, error)
+	DropDatabase(ctx context.Context, name string, token string, force bool) error
+}
+
+// RenameDatabaseRequest holds the new name of a database
+type RenameDatabaseRequest struct {
+	Name string `json:"name"`
+}
+
+// DropDatabaseRequest carries the token issued by the preview endpoint
+type DropDatabaseRequest struct {
+	Token string `json:"token"`
+	Force bool   `json:"force"`
+}
+
+// @Summary Get list of databases
+// @Description Returns the databases of the server with size, owner, encoding, collation and connection limit
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Database
+// @Failure 500 {object} ErrorResponse
+// @Router /databases [get]
+func (h *Handler) Databases(c *gin.Context) {
+	h.logger.Info("Databases request received")
+	databases, err := h.service.Databases(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list databases", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"databases": databases})
+}
+
+// @Summary Create database
+// @Description Creates a database, optionally from a template
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Param request body domain.DatabaseCreate true "Database definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /databases [post]
+func (h *Handler) CreateDatabase(c *gin.Context) {
+	h.logger.Info("CreateDatabase request received")
+	var request domain.DatabaseCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreateDatabase(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create database", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Database created successfully"})
+	h.logger.Info("Database created successfully", "name", request.Name)
+}
+
+// @Summary Rename database
+// @Description Renames a database no registered connection profile uses
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Param name path string true "Database name"
+// @Param request body RenameDatabaseRequest true "New name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /databases/{name} [patch]
+func (h *Handler) RenameDatabase(c *gin.Context) {
+	h.logger.Info("RenameDatabase request received")
+	name := c.Param("name")
+	var request RenameDatabaseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.RenameDatabase(c, name, request.Name)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to rename database", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Database renamed successfully"})
+	h.logger.Info("Database renamed successfully", "name", name, "new_name", request.Name)
+}
+
+// @Summary Preview dropping a database
+// @Description Describes the database that would be dropped and issues a short-lived confirmation token. Databases used by a registered connection profile cannot be dropped
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Param name path string true "Database name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.DatabaseDropPreview
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /databases/{name}/drop/preview [post]
+func (h *Handler) PreviewDropDatabase(c *gin.Context) {
+	h.logger.Info("PreviewDropDatabase request received")
+	preview, err := h.service.PreviewDropDatabase(c, c.Param("name"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to preview dropping the database", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, preview)
+}
+
+// @Summary Drop database
+// @Description Drops a database. Requires the token issued by the preview endpoint. With force the sessions connected to it are terminated first
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Param name path string true "Database name"
+// @Param request body DropDatabaseRequest true "Confirmation"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /databases/{name} [delete]
+func (h *Handler) DropDatabase(c *gin.Context) {
+	h.logger.Info("DropDatabase request received")
+	name := c.Param("name")
+	var request DropDatabaseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.DropDatabase(c, name, request.Token, request.Force)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop database", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Database dropped successfully"})
+	h.logger.Warn("Database dropped", "name", name)
+}
@@ -2,7 +2,9 @@ package rest
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"l6/internal/domain"
 	"log/slog"
 	"net/http"
@@ -17,14 +19,40 @@ import (
 // @BasePath /
 
 type Service interface {
+	ConnectionService
+	DatabaseService
+	WipeService
+	SchemaService
+	MigrationService
+	DDLService
+	IndexService
+	ObjectService
+	SequenceService
+	TypeService
+	RoleService
+	RLSService
+	ActivityService
+	HealthService
+	StatementsService
+	TableStatsService
+	SettingsService
+	ExtensionService
+	ReplicationService
+	LogicalReplicationService
+	JobService
 	Tables(ctx context.Context) ([]string, error)
 	ExecuteQuery(ctx context.Context, query string) (string, error)
 	ListBackups(ctx context.Context) ([]domain.Backup, error)
-	CreateBackup(ctx context.Context) (domain.BackupCreated, error)
+	CreateBackup(ctx context.Context, opts domain.BackupOptions) (domain.Job, error)
 	DownloadBackup(ctx context.Context, filename string) ([]byte, error)
 	DeleteBackup(ctx context.Context, filename string) error
-	RestoreBackup(ctx context.Context, filename string) error
-	DeleteAllTables(ctx context.Context) error
+	RestoreBackup(ctx context.Context, filename string, opts domain.RestoreOptions) (domain.Job, error)
+	BackupContents(ctx context.Context, filename string) (domain.BackupContents, error)
+}
+
+type WipeService interface {
+	PreviewDeleteAllTables(ctx context.Context) (domain.WipePreview, error)
+	DeleteAllTables(ctx context.Context, token string, opts domain.WipeOptions) (domain.Job, error)
 }
 
 type Handler struct {
@@ -37,14 +65,101 @@ func NewHandler(service Service, logger *slog.Logger) *Handler {
 }
 
 func (h *Handler) InitRoutes(router *gin.Engine) {
-	router.GET("/tables", h.Tables)
-	router.POST("/execute", h.Execute)
-	router.GET("/backup/list", h.ListBackups)
-	router.POST("/backup/create", h.CreateBackup)
-	router.GET("/backup/download/:filename", h.DownloadBackup)
-	router.DELETE("/backup/delete/:filename", h.DeleteBackup)
-	router.POST("/backup/restore/:filename", h.RestoreBackup)
-	router.DELETE("/tables/delete/all", h.DeleteAllTables)
+	router.GET("/connections", h.ListConnections)
+	router.POST("/connections", h.AddConnection)
+	router.PUT("/connections/:id", h.UpdateConnection)
+	router.DELETE("/connections/:id", h.RemoveConnection)
+	router.POST("/connections/:id/test", h.TestConnection)
+
+	router.GET("/jobs", h.Jobs)
+	router.GET("/jobs/:id", h.Job)
+	router.POST("/jobs/:id/cancel", h.CancelJob)
+
+	db := router.Group("", h.connectionMiddleware)
+	db.GET("/tables", h.Tables)
+	db.POST("/tables", h.CreateTable)
+	db.PATCH("/tables/:table", h.AlterTable)
+	db.POST("/execute", h.Execute)
+	db.GET("/backup/list", h.ListBackups)
+	db.POST("/backup/create", h.CreateBackup)
+	db.GET("/backup/download/:filename", h.DownloadBackup)
+	db.DELETE("/backup/delete/:filename", h.DeleteBackup)
+	db.POST("/backup/restore/:filename", h.RestoreBackup)
+	db.GET("/backup/:filename/contents", h.BackupContents)
+	db.POST("/tables/delete/all/preview", h.PreviewDeleteAllTables)
+	db.DELETE("/tables/delete/all", h.DeleteAllTables)
+	db.GET("/databases", h.Databases)
+	db.POST("/databases", h.CreateDatabase)
+	db.PATCH("/databases/:name", h.RenameDatabase)
+	db.DELETE("/databases/:name", h.DropDatabase)
+	db.POST("/databases/:name/drop/preview", h.PreviewDropDatabase)
+	db.GET("/schema/snapshot", h.SchemaSnapshot)
+	db.POST("/schema/diff", h.DiffSchemas)
+	db.GET("/migrations", h.MigrationStatus)
+	db.POST("/migrations/up", h.MigrateUp)
+	db.POST("/migrations/down", h.MigrateDown)
+	db.GET("/indexes", h.Indexes)
+	db.POST("/indexes", h.CreateIndex)
+	db.DELETE("/indexes/:name", h.DropIndex)
+	db.POST("/indexes/:name/reindex", h.ReindexIndex)
+	db.GET("/views", h.Views)
+	db.GET("/views/:name", h.View)
+	db.POST("/views/:name/refresh", h.RefreshMaterializedView)
+	db.GET("/functions", h.Functions)
+	db.GET("/functions/:name", h.Function)
+	db.GET("/triggers", h.Triggers)
+	db.GET("/sequences", h.Sequences)
+	db.POST("/sequences/resync", h.ResyncSequences)
+	db.GET("/types", h.Types)
+	db.POST("/types/enums", h.CreateEnum)
+	db.POST("/types/enums/:name/values", h.AddEnumValue)
+	db.PATCH("/types/enums/:name/values", h.RenameEnumValue)
+	db.POST("/types/domains", h.CreateDomain)
+	db.GET("/roles", h.Roles)
+	db.POST("/roles", h.CreateRole)
+	db.PATCH("/roles/:name", h.AlterRole)
+	db.PUT("/roles/:name/password", h.SetRolePassword)
+	db.DELETE("/roles/:name", h.DropRole)
+	db.GET("/privileges", h.PrivilegeMatrix)
+	db.POST("/privileges/grant", h.GrantPrivileges)
+	db.POST("/privileges/revoke", h.RevokePrivileges)
+	db.GET("/rls", h.TablesRLS)
+	db.PATCH("/tables/:table/rls", h.SetTableRLS)
+	db.GET("/policies", h.Policies)
+	db.POST("/tables/:table/policies", h.CreatePolicy)
+	db.PATCH("/tables/:table/policies/:name", h.AlterPolicy)
+	db.DELETE("/tables/:table/policies/:name", h.DropPolicy)
+	db.GET("/activity", h.Activity)
+	db.POST("/activity/:pid/cancel", h.CancelBackend)
+	db.POST("/activity/:pid/terminate", h.TerminateBackend)
+	db.GET("/locks", h.Locks)
+	db.GET("/health/database", h.DatabaseHealth)
+	db.GET("/statements", h.TopStatements)
+	db.GET("/statements/snapshots", h.StatementsSnapshots)
+	db.POST("/statements/snapshots", h.TakeStatementsSnapshot)
+	db.POST("/statements/reset", h.ResetStatements)
+	db.GET("/tables/stats", h.TableStatsReport)
+	db.GET("/tables/:table/stats", h.TableStats)
+	db.POST("/tables/:table/vacuum", h.VacuumTable)
+	db.POST("/tables/:table/analyze", h.AnalyzeTable)
+	db.GET("/settings", h.Settings)
+	db.GET("/settings/pending", h.PendingSettings)
+	db.PUT("/settings/:name", h.SetSetting)
+	db.DELETE("/settings/:name", h.ResetSetting)
+	db.GET("/extensions", h.Extensions)
+	db.POST("/extensions", h.CreateExtension)
+	db.PATCH("/extensions/:name", h.AlterExtension)
+	db.DELETE("/extensions/:name", h.DropExtension)
+	db.GET("/replication", h.ReplicationStatus)
+	db.GET("/publications", h.Publications)
+	db.POST("/publications", h.CreatePublication)
+	db.PATCH("/publications/:name", h.AlterPublication)
+	db.DELETE("/publications/:name", h.DropPublication)
+	db.GET("/subscriptions", h.Subscriptions)
+	db.POST("/subscriptions", h.CreateSubscription)
+	db.PATCH("/subscriptions/:name", h.AlterSubscription)
+	db.POST("/subscriptions/:name/refresh", h.RefreshSubscription)
+	db.DELETE("/subscriptions/:name", h.DropSubscription)
 }
 
 // TableResponse represents the response for the tables endpoint
@@ -57,11 +172,26 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// errorStatus maps domain errors to HTTP status codes.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrConnectionNotFound), errors.Is(err, domain.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrConnectionExists), errors.Is(err, domain.ErrConflict):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // @Summary Get list of tables
 // @Description Returns a list of all tables in the database
 // @Tags tables
 // @Accept json
 // @Produce json
+// @Param connection query string false "Connection ID"
 // @Success 200 {object} TableResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /tables [get]
@@ -85,6 +215,7 @@ type ExecuteRequest struct {
 // @Accept json
 // @Produce json
 // @Param request body ExecuteRequest true "SQL query"
+// @Param connection query string false "Connection ID"
 // @Success 200 {object} map[string]string
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
@@ -109,10 +240,11 @@ func (h *Handler) Execute(c *gin.Context) {
 }
 
 // @Summary Get list of backups
-// @Description Returns a list of all available backups
+// @Description Returns a list of all available backups with their format and the options they were created with
 // @Tags backup
 // @Accept json
 // @Produce json
+// @Param connection query string false "Connection ID"
 // @Success 200 {object} map[string][]domain.Backup
 // @Failure 500 {object} ErrorResponse
 // @Router /backup/list [get]
@@ -129,32 +261,44 @@ func (h *Handler) ListBackups(c *gin.Context) {
 }
 
 // @Summary Create new backup
-// @Description Creates a new backup of the database
+// @Description Queues a new backup of the database in plain SQL, custom (-Fc), directory (-Fd) or tar format and returns the background job running pg_dump, see /jobs/{id}. Directory backups can be dumped with parallel jobs. The dump can be limited to the schema or the data, include or exclude tables and schemas, omit ownership and privileges, and drop objects before creating them. The options are recorded in a sidecar metadata file shown by the backup list. Without a body a full plain SQL backup is created
 // @Tags backup
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]domain.BackupCreated
+// @Param request body domain.BackupOptions false "Backup format and contents"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /backup/create [post]
 func (h *Handler) CreateBackup(c *gin.Context) {
 	h.logger.Info("CreateBackup request received")
-	backup, err := h.service.CreateBackup(c)
+	var request domain.BackupOptions
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	job, err := h.service.CreateBackup(c, request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
 		h.logger.Error("Failed to create backup", "error", err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"backup": backup})
-	h.logger.Info("Backup created successfully")
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Backup queued successfully", "job", job.ID)
 }
 
 // @Summary Download backup
-// @Description Downloads a specific backup file
+// @Description Downloads a specific backup file. Directory format backups cannot be downloaded
 // @Tags backup
 // @Accept json
 // @Produce application/sql
 // @Param filename path string true "Backup filename"
+// @Param connection query string false "Connection ID"
 // @Success 200 {file} application/sql
+// @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /backup/download/{filename} [get]
 func (h *Handler) DownloadBackup(c *gin.Context) {
@@ -162,7 +306,7 @@ func (h *Handler) DownloadBackup(c *gin.Context) {
 	filename := c.Param("filename")
 	backup, err := h.service.DownloadBackup(c, filename)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
 		h.logger.Error("Failed to download backup", "error", err)
 		return
 	}
@@ -178,6 +322,7 @@ func (h *Handler) DownloadBackup(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param filename path string true "Backup filename"
+// @Param connection query string false "Connection ID"
 // @Success 200 {object} domain.BackupDeleted
 // @Failure 500 {object} ErrorResponse
 // @Router /backup/delete/{filename} [delete]
@@ -196,43 +341,114 @@ func (h *Handler) DeleteBackup(c *gin.Context) {
 }
 
 // @Summary Restore backup
-// @Description Restores the database from a specific backup
+// @Description Queues the restore of the database from a specific backup and returns the background job running it, see /jobs/{id}. The format is detected from the content: plain SQL dumps are restored with psql, custom, directory and tar archives with pg_restore. Archive backups can be restored selectively by schema, table and entry type, into another database or, for a single schema, under another schema name, with --clean, --single-transaction or parallel jobs. exit_on_error stops any restore at the first failing statement. Without a body the whole backup is restored
 // @Tags backup
 // @Accept json
 // @Produce json
 // @Param filename path string true "Backup filename"
-// @Success 200 {object} domain.BackupCreated
+// @Param request body domain.RestoreOptions false "Restore selection and target"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /backup/restore/{filename} [post]
 func (h *Handler) RestoreBackup(c *gin.Context) {
 	h.logger.Info("RestoreBackup request received")
 	filename := c.Param("filename")
-	err := h.service.RestoreBackup(c, filename)
+	var request domain.RestoreOptions
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	job, err := h.service.RestoreBackup(c, filename, request)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
 		h.logger.Error("Failed to restore backup", "error", err)
 		return
 	}
-	c.JSON(http.StatusOK, domain.BackupCreated{Success: true, Message: "Backup restored successfully"})
-	h.logger.Info("Backup restored successfully", "filename", filename)
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Backup restore queued successfully", "filename", filename, "job", job.ID)
+}
+
+// @Summary Get backup contents
+// @Description Lists the entries of a custom, directory or tar backup with pg_restore -l, to choose what to restore
+// @Tags backup
+// @Accept json
+// @Produce json
+// @Param filename path string true "Backup filename"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.BackupContents
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /backup/{filename}/contents [get]
+func (h *Handler) BackupContents(c *gin.Context) {
+	h.logger.Info("BackupContents request received")
+	contents, err := h.service.BackupContents(c, c.Param("filename"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list backup contents", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, contents)
+}
+
+// DeleteAllTablesRequest carries the token issued by the preview endpoint and
+// the kinds of objects to remove besides tables
+type DeleteAllTablesRequest struct {
+	Token   string             `json:"token"`
+	Include domain.WipeOptions `json:"include"`
+}
+
+// @Summary Preview deletion of all tables
+// @Description Lists the tables, dependent views and sequences that would be dropped and issues a short-lived confirmation token
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.WipePreview
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/delete/all/preview [post]
+func (h *Handler) PreviewDeleteAllTables(c *gin.Context) {
+	h.logger.Info("PreviewDeleteAllTables request received")
+	preview, err := h.service.PreviewDeleteAllTables(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to preview deletion of all tables", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, preview)
 }
 
 // @Summary Delete all tables
-// @Description Deletes all tables from the database
+// @Description Queues a job deleting all tables of the public schema after taking a backup, see /jobs/{id}. Sequences, views, materialized views, types and functions are removed when included. Requires the token issued by the preview endpoint. The domain.TablesDeleted report is the result of the finished job
 // @Tags tables
 // @Accept json
 // @Produce json
-// @Success 200 {object} map[string]string
+// @Param request body DeleteAllTablesRequest true "Confirmation token"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /tables/delete/all [delete]
 func (h *Handler) DeleteAllTables(c *gin.Context) {
 	h.logger.Info("DeleteAllTables request received")
-	err := h.service.DeleteAllTables(c)
+	var request DeleteAllTablesRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	job, err := h.service.DeleteAllTables(c, request.Token, request.Include)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
 		h.logger.Error("Failed to delete all tables", "error", err)
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{"message": "All tables deleted successfully"})
-	h.logger.Info("All tables deleted successfully")
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Deletion of all tables queued successfully", "job", job.ID)
 }
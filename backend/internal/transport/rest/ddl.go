@@ -0,0 +1,83 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DDLService interface {
+	CreateTable(ctx context.Context, table domain.TableDefinition, preview bool) (domain.DDLResult, error)
+	AlterTable(ctx context.Context, table string, alteration domain.TableAlteration, preview bool) (domain.DDLResult, error)
+}
+
+// @Summary Create table
+// @Description Generates quoted CREATE TABLE and COMMENT statements from a typed definition and applies them. With preview=true only the generated SQL is returned
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param request body domain.TableDefinition true "Table definition"
+// @Param preview query bool false "Return the generated SQL without applying it"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.DDLResult
+// @Success 201 {object} domain.DDLResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables [post]
+func (h *Handler) CreateTable(c *gin.Context) {
+	h.logger.Info("CreateTable request received")
+	var request domain.TableDefinition
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	result, err := h.service.CreateTable(c, request, c.Query("preview") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create table", "error", err)
+		return
+	}
+	if !result.Applied {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+	c.JSON(http.StatusCreated, result)
+	h.logger.Info("Table created successfully", "table", request.Name)
+}
+
+// @Summary Alter table
+// @Description Generates quoted ALTER TABLE statements (drop, rename, add and alter columns, change types with USING, add and drop constraints, comments) and applies them in one transaction. With preview=true only the generated SQL is returned
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param request body domain.TableAlteration true "Table changes"
+// @Param preview query bool false "Return the generated SQL without applying it"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.DDLResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table} [patch]
+func (h *Handler) AlterTable(c *gin.Context) {
+	h.logger.Info("AlterTable request received")
+	table := c.Param("table")
+	var request domain.TableAlteration
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	result, err := h.service.AlterTable(c, table, request, c.Query("preview") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to alter table", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+	if result.Applied {
+		h.logger.Info("Table altered successfully", "table", table)
+	}
+}
@@ -0,0 +1,125 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExtensionService interface {
+	Extensions(ctx context.Context, installed bool) ([]domain.Extension, error)
+	CreateExtension(ctx context.Context, create domain.ExtensionCreate) error
+	AlterExtension(ctx context.Context, name string, alter domain.ExtensionAlter) error
+	DropExtension(ctx context.Context, name string, cascade bool) error
+}
+
+// @Summary Get list of extensions
+// @Description Returns the extensions available to the server with their default, installed and available versions and the schema they are installed in
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param installed query bool false "Only installed extensions"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Extension
+// @Failure 500 {object} ErrorResponse
+// @Router /extensions [get]
+func (h *Handler) Extensions(c *gin.Context) {
+	h.logger.Info("Extensions request received")
+	extensions, err := h.service.Extensions(c, c.Query("installed") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list extensions", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"extensions": extensions})
+}
+
+// @Summary Create extension
+// @Description Installs an extension into the current database, optionally into a schema, at a version and with the extensions it requires
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param request body domain.ExtensionCreate true "Extension to install"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extensions [post]
+func (h *Handler) CreateExtension(c *gin.Context) {
+	h.logger.Info("CreateExtension request received")
+	var request domain.ExtensionCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreateExtension(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create extension", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Extension created successfully"})
+	h.logger.Info("Extension created successfully", "name", request.Name)
+}
+
+// @Summary Alter extension
+// @Description Updates an installed extension to a version, the default one when only update is set, and moves relocatable extensions to another schema
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param name path string true "Extension name"
+// @Param request body domain.ExtensionAlter true "Changes"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extensions/{name} [patch]
+func (h *Handler) AlterExtension(c *gin.Context) {
+	h.logger.Info("AlterExtension request received")
+	name := c.Param("name")
+	var request domain.ExtensionAlter
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.AlterExtension(c, name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to alter extension", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Extension altered successfully"})
+	h.logger.Info("Extension altered successfully", "name", name)
+}
+
+// @Summary Drop extension
+// @Description Drops an installed extension, with cascade also the objects depending on it
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param name path string true "Extension name"
+// @Param cascade query bool false "Drop dependent objects"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /extensions/{name} [delete]
+func (h *Handler) DropExtension(c *gin.Context) {
+	h.logger.Info("DropExtension request received")
+	name := c.Param("name")
+	err := h.service.DropExtension(c, name, c.Query("cascade") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop extension", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Extension dropped successfully"})
+	h.logger.Info("Extension dropped successfully", "name", name)
+}
@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type HealthService interface {
+	DatabaseHealth(ctx context.Context) (domain.DatabaseHealth, error)
+}
+
+// @Summary Get database health
+// @Description Aggregates cache hit ratio, transaction rate, connections, replication lag, oldest transaction, wraparound risk, dead tuples, long-running queries and WAL generation, evaluated against the configured thresholds. Rates are computed against the previous call
+// @Tags health
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.DatabaseHealth
+// @Failure 500 {object} ErrorResponse
+// @Router /health/database [get]
+func (h *Handler) DatabaseHealth(c *gin.Context) {
+	h.logger.Info("DatabaseHealth request received")
+	health, err := h.service.DatabaseHealth(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get database health", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, health)
+}
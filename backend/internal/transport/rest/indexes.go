@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type IndexService interface {
+	Indexes(ctx context.Context, schemas []string, table string) ([]domain.Index, error)
+	CreateIndex(ctx context.Context, index domain.IndexCreate) (domain.Job, error)
+	DropIndex(ctx context.Context, schema, name string, concurrently, cascade bool) error
+	ReindexIndex(ctx context.Context, schema, name string, concurrently bool) (domain.Job, error)
+}
+
+// @Summary Get list of indexes
+// @Description Returns indexes with definition, size, scans, tuples read, validity and a bloat estimate. Unused indexes and duplicates of other indexes are flagged
+// @Tags indexes
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param table query string false "Table name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Index
+// @Failure 500 {object} ErrorResponse
+// @Router /indexes [get]
+func (h *Handler) Indexes(c *gin.Context) {
+	h.logger.Info("Indexes request received")
+	indexes, err := h.service.Indexes(c, c.QueryArray("schema"), c.Query("table"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list indexes", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"indexes": indexes})
+}
+
+// @Summary Create index
+// @Description Queues a job creating an index on columns and expressions, optionally unique, partial, covering or concurrently, see /jobs/{id}. Methods: btree, gin, gist, brin. Cancelling a concurrent build leaves an invalid index to drop
+// @Tags indexes
+// @Accept json
+// @Produce json
+// @Param request body domain.IndexCreate true "Index definition"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /indexes [post]
+func (h *Handler) CreateIndex(c *gin.Context) {
+	h.logger.Info("CreateIndex request received")
+	var request domain.IndexCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	job, err := h.service.CreateIndex(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create index", "error", err)
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Index build queued successfully", "table", request.Table, "name", request.Name, "job", job.ID)
+}
+
+// @Summary Drop index
+// @Description Drops an index, optionally concurrently or with cascade
+// @Tags indexes
+// @Accept json
+// @Produce json
+// @Param name path string true "Index name"
+// @Param schema query string false "Schema, public by default"
+// @Param concurrently query bool false "Drop without locking out writes"
+// @Param cascade query bool false "Drop dependent objects"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /indexes/{name} [delete]
+func (h *Handler) DropIndex(c *gin.Context) {
+	h.logger.Info("DropIndex request received")
+	name := c.Param("name")
+	err := h.service.DropIndex(c, c.Query("schema"), name, c.Query("concurrently") == "true", c.Query("cascade") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop index", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Index dropped successfully"})
+	h.logger.Info("Index dropped successfully", "name", name)
+}
+
+// @Summary Reindex index
+// @Description Queues a job rebuilding an index, optionally concurrently, see /jobs/{id}
+// @Tags indexes
+// @Accept json
+// @Produce json
+// @Param name path string true "Index name"
+// @Param schema query string false "Schema, public by default"
+// @Param concurrently query bool false "Rebuild without locking out writes"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /indexes/{name}/reindex [post]
+func (h *Handler) ReindexIndex(c *gin.Context) {
+	h.logger.Info("ReindexIndex request received")
+	name := c.Param("name")
+	job, err := h.service.ReindexIndex(c, c.Query("schema"), name, c.Query("concurrently") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to reindex index", "error", err)
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Index rebuild queued successfully", "name", name, "job", job.ID)
+}
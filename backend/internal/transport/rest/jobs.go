@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type JobService interface {
+	Jobs(ctx context.Context) ([]domain.Job, error)
+	Job(ctx context.Context, id string) (domain.Job, error)
+	CancelJob(ctx context.Context, id string) (domain.Job, error)
+}
+
+// @Summary List jobs
+// @Description Lists the queued, running and recently finished backup, restore, wipe and maintenance jobs of all connections, newest first
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string][]domain.Job
+// @Failure 500 {object} ErrorResponse
+// @Router /jobs [get]
+func (h *Handler) Jobs(c *gin.Context) {
+	h.logger.Info("Jobs request received")
+	jobs, err := h.service.Jobs(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list jobs", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"jobs": jobs})
+}
+
+// @Summary Get job
+// @Description Reports the state of a background job with its elapsed time, the bytes written so far by a backup, the result of a finished wipe and the stderr of the client tool
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} domain.Job
+// @Failure 404 {object} ErrorResponse
+// @Router /jobs/{id} [get]
+func (h *Handler) Job(c *gin.Context) {
+	h.logger.Info("Job request received")
+	job, err := h.service.Job(c, c.Param("id"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get job", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// @Summary Cancel job
+// @Description Cancels a queued or running job. A running pg_dump, pg_restore or psql is killed and a partially written backup is removed
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} domain.Job
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Router /jobs/{id}/cancel [post]
+func (h *Handler) CancelJob(c *gin.Context) {
+	h.logger.Info("CancelJob request received")
+	id := c.Param("id")
+	job, err := h.service.CancelJob(c, id)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to cancel job", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, job)
+	h.logger.Info("Job cancelled successfully", "id", id)
+}
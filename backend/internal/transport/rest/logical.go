@@ -0,0 +1,251 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LogicalReplicationService interface {
+	Publications(ctx context.Context) ([]domain.Publication, error)
+	CreatePublication(ctx context.Context, create domain.PublicationCreate) error
+	AlterPublication(ctx context.Context, name string, alter domain.PublicationAlter) error
+	DropPublication(ctx context.Context, name string) error
+	Subscriptions(ctx context.Context) ([]domain.Subscription, error)
+	CreateSubscription(ctx context.Context, create domain.SubscriptionCreate) error
+	AlterSubscription(ctx context.Context, name string, alter domain.SubscriptionAlter) error
+	RefreshSubscription(ctx context.Context, name string, copyData bool) error
+	DropSubscription(ctx context.Context, name string) error
+}
+
+// @Summary Get list of publications
+// @Description Returns logical replication publications with their published operations and tables
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Publication
+// @Failure 500 {object} ErrorResponse
+// @Router /publications [get]
+func (h *Handler) Publications(c *gin.Context) {
+	h.logger.Info("Publications request received")
+	publications, err := h.service.Publications(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list publications", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"publications": publications})
+}
+
+// @Summary Create publication
+// @Description Creates a publication of all tables or of the given tables, optionally limited to some of insert, update, delete and truncate
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param request body domain.PublicationCreate true "Publication definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /publications [post]
+func (h *Handler) CreatePublication(c *gin.Context) {
+	h.logger.Info("CreatePublication request received")
+	var request domain.PublicationCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreatePublication(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create publication", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Publication created successfully"})
+	h.logger.Info("Publication created successfully", "name", request.Name)
+}
+
+// @Summary Alter publication
+// @Description Adds and drops tables, changes the published operations and renames a publication in a single transaction
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param name path string true "Publication name"
+// @Param request body domain.PublicationAlter true "Changes"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /publications/{name} [patch]
+func (h *Handler) AlterPublication(c *gin.Context) {
+	h.logger.Info("AlterPublication request received")
+	name := c.Param("name")
+	var request domain.PublicationAlter
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.AlterPublication(c, name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to alter publication", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Publication altered successfully"})
+	h.logger.Info("Publication altered successfully", "name", name)
+}
+
+// @Summary Drop publication
+// @Description Drops a publication
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param name path string true "Publication name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} ErrorResponse
+// @Router /publications/{name} [delete]
+func (h *Handler) DropPublication(c *gin.Context) {
+	h.logger.Info("DropPublication request received")
+	name := c.Param("name")
+	err := h.service.DropPublication(c, name)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop publication", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Publication dropped successfully"})
+	h.logger.Info("Publication dropped successfully", "name", name)
+}
+
+// @Summary Get list of subscriptions
+// @Description Returns the subscriptions of the current database with their enabled flag, publications, apply worker status and the number of tables still synchronizing. Connection strings are not returned
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Subscription
+// @Failure 500 {object} ErrorResponse
+// @Router /subscriptions [get]
+func (h *Handler) Subscriptions(c *gin.Context) {
+	h.logger.Info("Subscriptions request received")
+	subscriptions, err := h.service.Subscriptions(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list subscriptions", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"subscriptions": subscriptions})
+}
+
+// @Summary Create subscription
+// @Description Creates a subscription to publications of another server, creating its replication slot on the publisher
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param request body domain.SubscriptionCreate true "Subscription definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /subscriptions [post]
+func (h *Handler) CreateSubscription(c *gin.Context) {
+	h.logger.Info("CreateSubscription request received")
+	var request domain.SubscriptionCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreateSubscription(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create subscription", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Subscription created successfully"})
+	h.logger.Info("Subscription created successfully", "name", request.Name)
+}
+
+// @Summary Alter subscription
+// @Description Replaces the subscribed publications and enables or disables a subscription
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param name path string true "Subscription name"
+// @Param request body domain.SubscriptionAlter true "Changes"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /subscriptions/{name} [patch]
+func (h *Handler) AlterSubscription(c *gin.Context) {
+	h.logger.Info("AlterSubscription request received")
+	name := c.Param("name")
+	var request domain.SubscriptionAlter
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.AlterSubscription(c, name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to alter subscription", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription altered successfully"})
+	h.logger.Info("Subscription altered successfully", "name", name)
+}
+
+// @Summary Refresh subscription
+// @Description Fetches the current table list of the subscribed publications, starting the replication of added tables
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param name path string true "Subscription name"
+// @Param copy_data query bool false "Copy the existing data of added tables" default(true)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} ErrorResponse
+// @Router /subscriptions/{name}/refresh [post]
+func (h *Handler) RefreshSubscription(c *gin.Context) {
+	h.logger.Info("RefreshSubscription request received")
+	name := c.Param("name")
+	err := h.service.RefreshSubscription(c, name, c.Query("copy_data") != "false")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to refresh subscription", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription refreshed successfully"})
+	h.logger.Info("Subscription refreshed successfully", "name", name)
+}
+
+// @Summary Drop subscription
+// @Description Drops a subscription and its replication slot on the publisher
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param name path string true "Subscription name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} ErrorResponse
+// @Router /subscriptions/{name} [delete]
+func (h *Handler) DropSubscription(c *gin.Context) {
+	h.logger.Info("DropSubscription request received")
+	name := c.Param("name")
+	err := h.service.DropSubscription(c, name)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop subscription", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription dropped successfully"})
+	h.logger.Info("Subscription dropped successfully", "name", name)
+}
@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"context"
+	"fmt"
+	"l6/internal/domain"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MigrationService interface {
+	MigrationStatus(ctx context.Context) ([]domain.Migration, error)
+	MigrateUp(ctx context.Context, to *int64) (domain.MigrationRun, error)
+	MigrateDown(ctx context.Context, to *int64) (domain.MigrationRun, error)
+}
+
+// targetVersion parses the optional "to" query parameter.
+func targetVersion(c *gin.Context) (*int64, error) {
+	value, ok := c.GetQuery("to")
+	if !ok || value == "" {
+		return nil, nil //nolint:nilnil // no target means the default target
+	}
+
+	to, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid target version %q", domain.ErrInvalidRequest, value)
+	}
+
+	return &to, nil
+}
+
+// @Summary Get migrations status
+// @Description Lists the migrations of the migrations directory and whether they are applied, modified or missing
+// @Tags migrations
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Migration
+// @Failure 500 {object} ErrorResponse
+// @Router /migrations [get]
+func (h *Handler) MigrationStatus(c *gin.Context) {
+	h.logger.Info("MigrationStatus request received")
+	migrations, err := h.service.MigrationStatus(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get migrations status", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"migrations": migrations})
+}
+
+// @Summary Apply migrations
+// @Description Applies pending migrations up to and including the target version, or all of them
+// @Tags migrations
+// @Accept json
+// @Produce json
+// @Param to query int false "Target version"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.MigrationRun
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /migrations/up [post]
+func (h *Handler) MigrateUp(c *gin.Context) {
+	h.logger.Info("MigrateUp request received")
+	to, err := targetVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	run, err := h.service.MigrateUp(c, to)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to apply migrations", "executed", len(run.Executed), "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, run)
+	h.logger.Info("Migrations applied", "count", len(run.Executed))
+}
+
+// @Summary Roll back migrations
+// @Description Rolls back applied migrations newer than the target version, or only the latest one
+// @Tags migrations
+// @Accept json
+// @Produce json
+// @Param to query int false "Target version"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.MigrationRun
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /migrations/down [post]
+func (h *Handler) MigrateDown(c *gin.Context) {
+	h.logger.Info("MigrateDown request received")
+	to, err := targetVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	run, err := h.service.MigrateDown(c, to)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to roll back migrations", "executed", len(run.Executed), "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, run)
+	h.logger.Info("Migrations rolled back", "count", len(run.Executed))
+}
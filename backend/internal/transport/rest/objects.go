@@ -0,0 +1,156 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ObjectService interface {
+	Views(ctx context.Context, schemas []string) ([]domain.View, error)
+	View(ctx context.Context, schema, name string) (domain.View, error)
+	RefreshMaterializedView(ctx context.Context, schema, name string, concurrently bool) (domain.Job, error)
+	Functions(ctx context.Context, schemas []string) ([]domain.Function, error)
+	Function(ctx context.Context, schema, name string) ([]domain.Function, error)
+	Triggers(ctx context.Context, schemas []string, table string) ([]domain.Trigger, error)
+}
+
+// @Summary Get list of views
+// @Description Returns views and materialized views with owner, size and whether they are populated
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.View
+// @Failure 500 {object} ErrorResponse
+// @Router /views [get]
+func (h *Handler) Views(c *gin.Context) {
+	h.logger.Info("Views request received")
+	views, err := h.service.Views(c, c.QueryArray("schema"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list views", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"views": views})
+}
+
+// @Summary Get view
+// @Description Returns a view or materialized view with its definition
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param name path string true "View name"
+// @Param schema query string false "Schema, public by default"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.View
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /views/{name} [get]
+func (h *Handler) View(c *gin.Context) {
+	h.logger.Info("View request received")
+	view, err := h.service.View(c, c.Query("schema"), c.Param("name"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get view", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, view)
+}
+
+// @Summary Refresh materialized view
+// @Description Queues a job refreshing a materialized view, optionally concurrently, see /jobs/{id}. A concurrent refresh needs a unique index on the view
+// @Tags views
+// @Accept json
+// @Produce json
+// @Param name path string true "Materialized view name"
+// @Param schema query string false "Schema, public by default"
+// @Param concurrently query bool false "Refresh without locking out reads"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /views/{name}/refresh [post]
+func (h *Handler) RefreshMaterializedView(c *gin.Context) {
+	h.logger.Info("RefreshMaterializedView request received")
+	name := c.Param("name")
+	job, err := h.service.RefreshMaterializedView(c, c.Query("schema"), name, c.Query("concurrently") == "true")
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to refresh materialized view", "error", err)
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Materialized view refresh queued successfully", "name", name, "job", job.ID)
+}
+
+// @Summary Get list of functions
+// @Description Returns functions and procedures with signature, result type, language and volatility
+// @Tags functions
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Function
+// @Failure 500 {object} ErrorResponse
+// @Router /functions [get]
+func (h *Handler) Functions(c *gin.Context) {
+	h.logger.Info("Functions request received")
+	functions, err := h.service.Functions(c, c.QueryArray("schema"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list functions", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"functions": functions})
+}
+
+// @Summary Get function
+// @Description Returns every overload of a function or procedure with its source and full definition
+// @Tags functions
+// @Accept json
+// @Produce json
+// @Param name path string true "Function name"
+// @Param schema query string false "Schema, public by default"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Function
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /functions/{name} [get]
+func (h *Handler) Function(c *gin.Context) {
+	h.logger.Info("Function request received")
+	functions, err := h.service.Function(c, c.Query("schema"), c.Param("name"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get function", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"functions": functions})
+}
+
+// @Summary Get list of triggers
+// @Description Returns user triggers with timing, events, level, function and definition
+// @Tags triggers
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param table query string false "Table name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Trigger
+// @Failure 500 {object} ErrorResponse
+// @Router /triggers [get]
+func (h *Handler) Triggers(c *gin.Context) {
+	h.logger.Info("Triggers request received")
+	triggers, err := h.service.Triggers(c, c.QueryArray("schema"), c.Query("table"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list triggers", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"triggers": triggers})
+}
@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReplicationService interface {
+	ReplicationStatus(ctx context.Context) (domain.ReplicationStatus, error)
+}
+
+// @Summary Get replication status
+// @Description Reports whether the server is a primary or a standby, its WAL senders from pg_stat_replication, its replication slots with the WAL they retain and, on a standby, the WAL receiver. Inactive slots, slots retaining more WAL than the configured threshold and lagging replicas produce warnings
+// @Tags replication
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.ReplicationStatus
+// @Failure 500 {object} ErrorResponse
+// @Router /replication [get]
+func (h *Handler) ReplicationStatus(c *gin.Context) {
+	h.logger.Info("ReplicationStatus request received")
+	status, err := h.service.ReplicationStatus(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get replication status", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
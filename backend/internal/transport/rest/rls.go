@@ -0,0 +1,183 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RLSService interface {
+	TablesRLS(ctx context.Context, schemas []string) ([]domain.TableRLS, error)
+	Policies(ctx context.Context, schemas []string, table string) ([]domain.Policy, error)
+	CreatePolicy(ctx context.Context, schema, table string, policy domain.PolicyCreate) error
+	AlterPolicy(ctx context.Context, schema, table, name string, alter domain.PolicyAlter) error
+	DropPolicy(ctx context.Context, schema, table, name string) error
+	SetTableRLS(ctx context.Context, schema, table string, change domain.RLSChange) error
+}
+
+// @Summary Get row-level security state
+// @Description Returns per table whether row-level security is enabled and forced and how many policies it has
+// @Tags rls
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.TableRLS
+// @Failure 500 {object} ErrorResponse
+// @Router /rls [get]
+func (h *Handler) TablesRLS(c *gin.Context) {
+	h.logger.Info("TablesRLS request received")
+	tables, err := h.service.TablesRLS(c, c.QueryArray("schema"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get row-level security state", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tables": tables})
+}
+
+// @Summary Set row-level security
+// @Description Enables or disables and forces or unforces row-level security on a table
+// @Tags rls
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param schema query string false "Schema, public by default"
+// @Param request body domain.RLSChange true "Row-level security state"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/rls [patch]
+func (h *Handler) SetTableRLS(c *gin.Context) {
+	h.logger.Info("SetTableRLS request received")
+	table := c.Param("table")
+	var request domain.RLSChange
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.SetTableRLS(c, c.Query("schema"), table, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to set row-level security", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Row-level security updated successfully"})
+	h.logger.Info("Row-level security updated successfully", "table", table)
+}
+
+// @Summary Get list of policies
+// @Description Returns row-level security policies with their roles, command and USING and WITH CHECK expressions
+// @Tags rls
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param table query string false "Table name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Policy
+// @Failure 500 {object} ErrorResponse
+// @Router /policies [get]
+func (h *Handler) Policies(c *gin.Context) {
+	h.logger.Info("Policies request received")
+	policies, err := h.service.Policies(c, c.QueryArray("schema"), c.Query("table"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list policies", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"policies": policies})
+}
+
+// @Summary Create policy
+// @Description Creates a row-level security policy on a table
+// @Tags rls
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param schema query string false "Schema, public by default"
+// @Param request body domain.PolicyCreate true "Policy definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/policies [post]
+func (h *Handler) CreatePolicy(c *gin.Context) {
+	h.logger.Info("CreatePolicy request received")
+	table := c.Param("table")
+	var request domain.PolicyCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreatePolicy(c, c.Query("schema"), table, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create policy", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Policy created successfully"})
+	h.logger.Info("Policy created successfully", "table", table, "name", request.Name)
+}
+
+// @Summary Alter policy
+// @Description Changes the roles and expressions of a policy and optionally renames it
+// @Tags rls
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param name path string true "Policy name"
+// @Param schema query string false "Schema, public by default"
+// @Param request body domain.PolicyAlter true "Policy changes"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/policies/{name} [patch]
+func (h *Handler) AlterPolicy(c *gin.Context) {
+	h.logger.Info("AlterPolicy request received")
+	table, name := c.Param("table"), c.Param("name")
+	var request domain.PolicyAlter
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.AlterPolicy(c, c.Query("schema"), table, name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to alter policy", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Policy altered successfully"})
+	h.logger.Info("Policy altered successfully", "table", table, "name", name)
+}
+
+// @Summary Drop policy
+// @Description Drops a row-level security policy
+// @Tags rls
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param name path string true "Policy name"
+// @Param schema query string false "Schema, public by default"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/policies/{name} [delete]
+func (h *Handler) DropPolicy(c *gin.Context) {
+	h.logger.Info("DropPolicy request received")
+	table, name := c.Param("table"), c.Param("name")
+	err := h.service.DropPolicy(c, c.Query("schema"), table, name)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop policy", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Policy dropped successfully"})
+	h.logger.Info("Policy dropped successfully", "table", table, "name", name)
+}
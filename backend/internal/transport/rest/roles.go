@@ -0,0 +1,241 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoleService interface {
+	Roles(ctx context.Context) ([]domain.Role, error)
+	CreateRole(ctx context.Context, role domain.RoleCreate) error
+	AlterRole(ctx context.Context, name string, alter domain.RoleAlter) error
+	SetRolePassword(ctx context.Context, name, password string) error
+	DropRole(ctx context.Context, name string) error
+	GrantPrivileges(ctx context.Context, change domain.PrivilegeChange) error
+	RevokePrivileges(ctx context.Context, change domain.PrivilegeChange) error
+	PrivilegeMatrix(ctx context.Context, schemas []string, objectType string) (domain.PrivilegeMatrix, error)
+}
+
+// SetRolePasswordRequest holds the new password of a role
+type SetRolePasswordRequest struct {
+	Password string `json:"password"`
+}
+
+// @Summary Get list of roles
+// @Description Returns roles with their attributes, the roles they are members of and their members
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Role
+// @Failure 500 {object} ErrorResponse
+// @Router /roles [get]
+func (h *Handler) Roles(c *gin.Context) {
+	h.logger.Info("Roles request received")
+	roles, err := h.service.Roles(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list roles", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"roles": roles})
+}
+
+// @Summary Create role
+// @Description Creates a role with the given attributes, password and memberships
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param request body domain.RoleCreate true "Role definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /roles [post]
+func (h *Handler) CreateRole(c *gin.Context) {
+	h.logger.Info("CreateRole request received")
+	var request domain.RoleCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreateRole(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create role", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Role created successfully"})
+	h.logger.Info("Role created successfully", "name", request.Name)
+}
+
+// @Summary Alter role
+// @Description Changes role attributes, grants or revokes memberships and optionally renames the role
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param name path string true "Role name"
+// @Param request body domain.RoleAlter true "Role changes"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /roles/{name} [patch]
+func (h *Handler) AlterRole(c *gin.Context) {
+	h.logger.Info("AlterRole request received")
+	name := c.Param("name")
+	var request domain.RoleAlter
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.AlterRole(c, name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to alter role", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role altered successfully"})
+	h.logger.Info("Role altered successfully", "name", name)
+}
+
+// @Summary Set role password
+// @Description Sets the password of a role
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param name path string true "Role name"
+// @Param request body SetRolePasswordRequest true "New password"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /roles/{name}/password [put]
+func (h *Handler) SetRolePassword(c *gin.Context) {
+	h.logger.Info("SetRolePassword request received")
+	name := c.Param("name")
+	var request SetRolePasswordRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.SetRolePassword(c, name, request.Password)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to set role password", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Password changed successfully"})
+	h.logger.Info("Password changed successfully", "name", name)
+}
+
+// @Summary Drop role
+// @Description Drops a role. The role used by the connection cannot be dropped
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param name path string true "Role name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /roles/{name} [delete]
+func (h *Handler) DropRole(c *gin.Context) {
+	h.logger.Info("DropRole request received")
+	name := c.Param("name")
+	err := h.service.DropRole(c, name)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to drop role", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Role dropped successfully"})
+	h.logger.Info("Role dropped successfully", "name", name)
+}
+
+// @Summary Get privilege matrix
+// @Description Returns the privileges every grantee holds on schemas, tables and sequences
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param type query string false "Object type: schema, table or sequence"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.PrivilegeMatrix
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /privileges [get]
+func (h *Handler) PrivilegeMatrix(c *gin.Context) {
+	h.logger.Info("PrivilegeMatrix request received")
+	matrix, err := h.service.PrivilegeMatrix(c, c.QueryArray("schema"), c.Query("type"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get privilege matrix", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, matrix)
+}
+
+// @Summary Grant privileges
+// @Description Grants privileges on a schema, or on tables or sequences of a schema (all of them when no objects are given)
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param request body domain.PrivilegeChange true "Privileges to grant"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /privileges/grant [post]
+func (h *Handler) GrantPrivileges(c *gin.Context) {
+	h.logger.Info("GrantPrivileges request received")
+	var request domain.PrivilegeChange
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.GrantPrivileges(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to grant privileges", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Privileges granted successfully"})
+	h.logger.Info("Privileges granted successfully", "roles", request.Roles)
+}
+
+// @Summary Revoke privileges
+// @Description Revokes privileges, or only the grant option, on a schema, or on tables or sequences of a schema
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param request body domain.PrivilegeChange true "Privileges to revoke"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /privileges/revoke [post]
+func (h *Handler) RevokePrivileges(c *gin.Context) {
+	h.logger.Info("RevokePrivileges request received")
+	var request domain.PrivilegeChange
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.RevokePrivileges(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to revoke privileges", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Privileges revoked successfully"})
+	h.logger.Info("Privileges revoked successfully", "roles", request.Roles)
+}
@@ -0,0 +1,73 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SchemaService interface {
+	SchemaSnapshot(ctx context.Context, schemas []string) (domain.SchemaSnapshot, error)
+	DiffSchemas(ctx context.Context, from, to domain.SchemaSource, schemas []string) (domain.SchemaDiff, error)
+}
+
+// SchemaDiffRequest names the two schemas to compare. Each side is either a
+// snapshot returned by /schema/snapshot or a connection ID; an empty side uses
+// the connection of the request
+type SchemaDiffRequest struct {
+	From    domain.SchemaSource `json:"from"`
+	To      domain.SchemaSource `json:"to"`
+	Schemas []string            `json:"schemas"`
+}
+
+// @Summary Get schema snapshot
+// @Description Captures tables, columns, constraints, indexes, views, functions, sequences, enums and triggers as JSON
+// @Tags schema
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to capture, all user schemas by default" collectionFormat(multi)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.SchemaSnapshot
+// @Failure 500 {object} ErrorResponse
+// @Router /schema/snapshot [get]
+func (h *Handler) SchemaSnapshot(c *gin.Context) {
+	h.logger.Info("SchemaSnapshot request received")
+	snapshot, err := h.service.SchemaSnapshot(c, c.QueryArray("schema"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to take schema snapshot", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// @Summary Diff schemas
+// @Description Compares two snapshots or connection profiles and generates a readable diff and a migration script from "from" to "to"
+// @Tags schema
+// @Accept json
+// @Produce json
+// @Param request body SchemaDiffRequest true "Schemas to compare"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.SchemaDiff
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /schema/diff [post]
+func (h *Handler) DiffSchemas(c *gin.Context) {
+	h.logger.Info("DiffSchemas request received")
+	var request SchemaDiffRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	diff, err := h.service.DiffSchemas(c, request.From, request.To, request.Schemas)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to diff schemas", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
@@ -0,0 +1,59 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SequenceService interface {
+	Sequences(ctx context.Context, schemas []string, table string) ([]domain.Sequence, error)
+	ResyncSequences(ctx context.Context, schemas []string, table string) ([]domain.SequenceResynced, error)
+}
+
+// @Summary Get list of sequences
+// @Description Returns sequences with their last value, owning column and the largest value used in that column. Sequences whose next value would collide are flagged as behind
+// @Tags sequences
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param table query string false "Owning table name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.Sequence
+// @Failure 500 {object} ErrorResponse
+// @Router /sequences [get]
+func (h *Handler) Sequences(c *gin.Context) {
+	h.logger.Info("Sequences request received")
+	sequences, err := h.service.Sequences(c, c.QueryArray("schema"), c.Query("table"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list sequences", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sequences": sequences})
+}
+
+// @Summary Resync sequences
+// @Description Sets owned sequences so that their next value is max(column)+1, for one table or for all tables of the schemas
+// @Tags sequences
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to resync, all user schemas by default" collectionFormat(multi)
+// @Param table query string false "Owning table name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.SequenceResynced
+// @Failure 500 {object} ErrorResponse
+// @Router /sequences/resync [post]
+func (h *Handler) ResyncSequences(c *gin.Context) {
+	h.logger.Info("ResyncSequences request received")
+	resynced, err := h.service.ResyncSequences(c, c.QueryArray("schema"), c.Query("table"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to resync sequences", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"sequences": resynced})
+	h.logger.Info("Sequences resynced successfully", "count", len(resynced))
+}
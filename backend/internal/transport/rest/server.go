@@ -35,6 +35,8 @@ func NewServer( //nolint:gocritic // no named params
 	l.Info("bind AppServer to host: %s and port: %s", s.cfg.Host, s.cfg.Port)
 
 	router := gin.New()
+	// Handlers pass *gin.Context as context.Context, so let it reach the request context values.
+	router.ContextWithFallback = true
 
 	router.Use(requestLoggerMiddleware(l))
 
@@ -0,0 +1,114 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SettingsService interface {
+	Settings(ctx context.Context, category string) ([]domain.SettingCategory, error)
+	PendingSettings(ctx context.Context) ([]domain.PendingSetting, error)
+	SetSetting(ctx context.Context, name string, change domain.SettingChange) (domain.SettingChanged, error)
+	ResetSetting(ctx context.Context, name string) (domain.SettingChanged, error)
+}
+
+// @Summary Get server settings
+// @Description Returns pg_settings grouped by category with value, unit, source, boot and reset values and whether a restart is pending
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param category query string false "Category prefix, case-insensitive"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.SettingCategory
+// @Failure 500 {object} ErrorResponse
+// @Router /settings [get]
+func (h *Handler) Settings(c *gin.Context) {
+	h.logger.Info("Settings request received")
+	categories, err := h.service.Settings(c, c.Query("category"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list settings", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"categories": categories})
+}
+
+// @Summary Get pending setting changes
+// @Description Lists values from the configuration files that differ from the running server, because they need a restart or are invalid. Reading pg_file_settings requires superuser
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.PendingSetting
+// @Failure 500 {object} ErrorResponse
+// @Router /settings/pending [get]
+func (h *Handler) PendingSettings(c *gin.Context) {
+	h.logger.Info("PendingSettings request received")
+	pending, err := h.service.PendingSettings(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list pending settings", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"pending": pending})
+}
+
+// @Summary Change setting
+// @Description Sets a parameter with ALTER SYSTEM and reloads the configuration. The reload is asynchronous, so the returned value may lag behind. A warning is returned when the change needs a restart or only applies to new sessions
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param name path string true "Parameter name"
+// @Param request body domain.SettingChange true "New value"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.SettingChanged
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /settings/{name} [put]
+func (h *Handler) SetSetting(c *gin.Context) {
+	h.logger.Info("SetSetting request received")
+	name := c.Param("name")
+	var request domain.SettingChange
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	result, err := h.service.SetSetting(c, name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to change setting", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+	h.logger.Info("Setting changed successfully", "name", name)
+}
+
+// @Summary Reset setting
+// @Description Removes a parameter from postgresql.auto.conf with ALTER SYSTEM RESET and reloads the configuration
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param name path string true "Parameter name"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.SettingChanged
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /settings/{name} [delete]
+func (h *Handler) ResetSetting(c *gin.Context) {
+	h.logger.Info("ResetSetting request received")
+	name := c.Param("name")
+	result, err := h.service.ResetSetting(c, name)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to reset setting", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+	h.logger.Info("Setting reset successfully", "name", name)
+}
@@ -0,0 +1,120 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StatementsService interface {
+	TopStatements(ctx context.Context, sortBy string, limit int, window time.Duration) (domain.StatementsReport, error)
+	StatementsSnapshots(ctx context.Context) ([]domain.StatementsSnapshot, error)
+	TakeStatementsSnapshot(ctx context.Context) (domain.StatementsSnapshot, error)
+	ResetStatements(ctx context.Context) error
+}
+
+// @Summary Get top statements
+// @Description Lists the top statements of pg_stat_statements with normalized text. With since the figures are deltas against the snapshot taken at least that long ago, or the oldest one kept. Installed is false when the extension is missing
+// @Tags statements
+// @Accept json
+// @Produce json
+// @Param sort query string false "Sort key: total_time, mean_time, calls, rows or io" default(total_time)
+// @Param limit query int false "Number of statements" default(20)
+// @Param since query string false "Time window as a duration, e.g. 1h"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.StatementsReport
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /statements [get]
+func (h *Handler) TopStatements(c *gin.Context) {
+	h.logger.Info("TopStatements request received")
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid limit"})
+			return
+		}
+	}
+	var window time.Duration
+	if raw := c.Query("since"); raw != "" {
+		var err error
+		if window, err = time.ParseDuration(raw); err != nil || window < 0 {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid since"})
+			return
+		}
+	}
+	report, err := h.service.TopStatements(c, c.Query("sort"), limit, window)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get statements", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary List statement snapshots
+// @Description Lists the pg_stat_statements snapshots kept for the connection, oldest first
+// @Tags statements
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string][]domain.StatementsSnapshot
+// @Failure 500 {object} ErrorResponse
+// @Router /statements/snapshots [get]
+func (h *Handler) StatementsSnapshots(c *gin.Context) {
+	h.logger.Info("StatementsSnapshots request received")
+	snapshots, err := h.service.StatementsSnapshots(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list statement snapshots", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"snapshots": snapshots})
+}
+
+// @Summary Take statement snapshot
+// @Description Snapshots pg_stat_statements now, in addition to the periodic snapshots
+// @Tags statements
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} domain.StatementsSnapshot
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /statements/snapshots [post]
+func (h *Handler) TakeStatementsSnapshot(c *gin.Context) {
+	h.logger.Info("TakeStatementsSnapshot request received")
+	snapshot, err := h.service.TakeStatementsSnapshot(c)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to take statement snapshot", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, snapshot)
+}
+
+// @Summary Reset statements
+// @Description Resets pg_stat_statements for the current database and drops the kept snapshots
+// @Tags statements
+// @Accept json
+// @Produce json
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /statements/reset [post]
+func (h *Handler) ResetStatements(c *gin.Context) {
+	h.logger.Info("ResetStatements request received")
+	if err := h.service.ResetStatements(c); err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to reset statements", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Statements reset"})
+	h.logger.Info("Statements reset")
+}
@@ -0,0 +1,117 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TableStatsService interface {
+	TableStatsReport(ctx context.Context, schemas []string) (domain.TableStatsReport, error)
+	TableStats(ctx context.Context, schema, table string) (domain.TableStats, error)
+	VacuumTable(ctx context.Context, schema, table string, opts domain.VacuumOptions) (domain.Job, error)
+	AnalyzeTable(ctx context.Context, schema, table string) error
+}
+
+// @Summary Get table statistics report
+// @Description Returns live and dead tuples, last vacuum and analyze, sequential and index scans, table, index and TOAST sizes and estimated bloat of all tables, largest first, with totals
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.TableStatsReport
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/stats [get]
+func (h *Handler) TableStatsReport(c *gin.Context) {
+	h.logger.Info("TableStatsReport request received")
+	report, err := h.service.TableStatsReport(c, c.QueryArray("schema"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get table statistics", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// @Summary Get table statistics
+// @Description Returns live and dead tuples, last vacuum and analyze, sequential and index scans, table, index and TOAST sizes and estimated bloat of a table
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param schema query string false "Schema, public by default"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.TableStats
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/stats [get]
+func (h *Handler) TableStats(c *gin.Context) {
+	h.logger.Info("TableStats request received")
+	stats, err := h.service.TableStats(c, c.Query("schema"), c.Param("table"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to get table statistics", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// @Summary Vacuum table
+// @Description Queues a job running VACUUM on a table, optionally FULL, FREEZE and ANALYZE, see /jobs/{id}. VACUUM FULL rewrites the table and blocks all access to it while running, cancelling the job stops it
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param schema query string false "Schema, public by default"
+// @Param full query bool false "Rewrite the table to reclaim space"
+// @Param freeze query bool false "Freeze all tuples"
+// @Param analyze query bool false "Update planner statistics"
+// @Param connection query string false "Connection ID"
+// @Success 202 {object} domain.Job
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/vacuum [post]
+func (h *Handler) VacuumTable(c *gin.Context) {
+	h.logger.Info("VacuumTable request received")
+	table := c.Param("table")
+	opts := domain.VacuumOptions{
+		Full:    c.Query("full") == "true",
+		Freeze:  c.Query("freeze") == "true",
+		Analyze: c.Query("analyze") == "true",
+	}
+	job, err := h.service.VacuumTable(c, c.Query("schema"), table, opts)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to vacuum table", "error", err)
+		return
+	}
+	c.JSON(http.StatusAccepted, job)
+	h.logger.Info("Table vacuum queued successfully", "table", table, "job", job.ID)
+}
+
+// @Summary Analyze table
+// @Description Runs ANALYZE on a table to update its planner statistics
+// @Tags tables
+// @Accept json
+// @Produce json
+// @Param table path string true "Table name"
+// @Param schema query string false "Schema, public by default"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 500 {object} ErrorResponse
+// @Router /tables/{table}/analyze [post]
+func (h *Handler) AnalyzeTable(c *gin.Context) {
+	h.logger.Info("AnalyzeTable request received")
+	table := c.Param("table")
+	err := h.service.AnalyzeTable(c, c.Query("schema"), table)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to analyze table", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Table analyzed successfully"})
+	h.logger.Info("Table analyzed successfully", "table", table)
+}
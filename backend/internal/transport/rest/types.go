@@ -0,0 +1,166 @@
+package rest
+
+import (
+	"context"
+	"l6/internal/domain"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TypeService interface {
+	Types(ctx context.Context, schemas []string) (domain.Types, error)
+	CreateEnum(ctx context.Context, enum domain.EnumCreate) error
+	AddEnumValue(ctx context.Context, schema, name string, value domain.EnumValueAdd) error
+	RenameEnumValue(ctx context.Context, schema, name, from, to string) error
+	CreateDomain(ctx context.Context, create domain.DomainCreate) error
+}
+
+// RenameEnumValueRequest renames an enum label
+type RenameEnumValueRequest struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// @Summary Get list of types
+// @Description Returns enums with their labels, composite types with their attributes and domains with their check constraints
+// @Tags types
+// @Accept json
+// @Produce json
+// @Param schema query []string false "Schemas to list, all user schemas by default" collectionFormat(multi)
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} domain.Types
+// @Failure 500 {object} ErrorResponse
+// @Router /types [get]
+func (h *Handler) Types(c *gin.Context) {
+	h.logger.Info("Types request received")
+	types, err := h.service.Types(c, c.QueryArray("schema"))
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to list types", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, types)
+}
+
+// @Summary Create enum
+// @Description Creates an enum type with the given labels
+// @Tags types
+// @Accept json
+// @Produce json
+// @Param request body domain.EnumCreate true "Enum definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /types/enums [post]
+func (h *Handler) CreateEnum(c *gin.Context) {
+	h.logger.Info("CreateEnum request received")
+	var request domain.EnumCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreateEnum(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create enum", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Enum created successfully"})
+	h.logger.Info("Enum created successfully", "name", request.Name)
+}
+
+// @Summary Add enum value
+// @Description Adds a label to an enum, at the end or before or after an existing label
+// @Tags types
+// @Accept json
+// @Produce json
+// @Param name path string true "Enum name"
+// @Param schema query string false "Schema, public by default"
+// @Param request body domain.EnumValueAdd true "New label"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /types/enums/{name}/values [post]
+func (h *Handler) AddEnumValue(c *gin.Context) {
+	h.logger.Info("AddEnumValue request received")
+	name := c.Param("name")
+	var request domain.EnumValueAdd
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.AddEnumValue(c, c.Query("schema"), name, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to add enum value", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Enum value added successfully"})
+	h.logger.Info("Enum value added successfully", "name", name, "value", request.Value)
+}
+
+// @Summary Rename enum value
+// @Description Renames a label of an enum
+// @Tags types
+// @Accept json
+// @Produce json
+// @Param name path string true "Enum name"
+// @Param schema query string false "Schema, public by default"
+// @Param request body RenameEnumValueRequest true "Old and new label"
+// @Param connection query string false "Connection ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /types/enums/{name}/values [patch]
+func (h *Handler) RenameEnumValue(c *gin.Context) {
+	h.logger.Info("RenameEnumValue request received")
+	name := c.Param("name")
+	var request RenameEnumValueRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.RenameEnumValue(c, c.Query("schema"), name, request.From, request.To)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to rename enum value", "error", err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Enum value renamed successfully"})
+	h.logger.Info("Enum value renamed successfully", "name", name, "from", request.From, "to", request.To)
+}
+
+// @Summary Create domain
+// @Description Creates a domain over a base type with an optional default, NOT NULL and check constraints
+// @Tags types
+// @Accept json
+// @Produce json
+// @Param request body domain.DomainCreate true "Domain definition"
+// @Param connection query string false "Connection ID"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /types/domains [post]
+func (h *Handler) CreateDomain(c *gin.Context) {
+	h.logger.Info("CreateDomain request received")
+	var request domain.DomainCreate
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to bind request", "error", err)
+		return
+	}
+	err := h.service.CreateDomain(c, request)
+	if err != nil {
+		c.JSON(errorStatus(err), ErrorResponse{Error: err.Error()})
+		h.logger.Error("Failed to create domain", "error", err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Domain created successfully"})
+	h.logger.Info("Domain created successfully", "name", request.Name)
+}
@@ -21,7 +21,7 @@ func InitDB(ctx context.Context, l *slog.Logger, cfg *config.PostgresConfig) (*s
 		cfg.Database,
 		cfg.Password,
 	)
-	l.Debug("postgres", "dsn", dsn)
+	l.Debug("postgres", "host", cfg.Host, "port", cfg.Port, "user", cfg.Username, "dbname", cfg.Database)
 
 	var db *sqlx.DB
 
@@ -41,6 +41,13 @@ func InitDB(ctx context.Context, l *slog.Logger, cfg *config.PostgresConfig) (*s
 		return nil, nil, fmt.Errorf("connect to postgres: %w", err)
 	}
 
+	if cfg.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+	}
+	if cfg.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(cfg.MaxIdleConns)
+	}
+
 	l.Debug("Successfully connected to postgresql")
 
 	shutdown := func(_ context.Context) error {
@@ -0,0 +1,56 @@
+package pgclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Output formats of pg_dump, named as its --format option names them.
+const (
+	FormatPlain     = "plain"
+	FormatCustom    = "custom"
+	FormatDirectory = "directory"
+	FormatTar       = "tar"
+)
+
+// DetectDumpFormat tells the pg_dump output format of path from its content:
+// a directory holding toc.dat, a custom archive starting with PGDMP, a tar archive
+// with the ustar magic, or a plain SQL script otherwise.
+func DetectDumpFormat(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", fmt.Errorf("stat dump: %w", err)
+	}
+	if info.IsDir() {
+		if _, err = os.Stat(filepath.Join(path, "toc.dat")); err != nil {
+			return "", fmt.Errorf("%s is not a directory format dump: %w", filepath.Base(path), err)
+		}
+		return FormatDirectory, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("open dump: %w", err)
+	}
+	defer f.Close()
+
+	header := make([]byte, 512)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read dump: %w", err)
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("PGDMP")):
+		return FormatCustom, nil
+	case len(header) >= 262 && bytes.Equal(header[257:262], []byte("ustar")):
+		return FormatTar, nil
+	default:
+		return FormatPlain, nil
+	}
+}
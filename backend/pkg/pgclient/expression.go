@@ -0,0 +1,101 @@
+package pgclient
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var dollarQuotePattern = regexp.MustCompile(`^\$([A-Za-z_][A-Za-z0-9_]*)?\$`)
+
+// CheckExpression makes sure a user supplied SQL expression stays within the
+// clause it is embedded into. It rejects statement separators and comments outside
+// of string literals, quoted identifiers and dollar quotes, unterminated quotes and
+// unbalanced parentheses. It does not parse the expression otherwise, the server
+// reports other syntax errors.
+func CheckExpression(expr string) error {
+	depth := 0
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case c == '\'':
+			end, ok := stringEnd(expr, i)
+			if !ok {
+				return errors.New("unterminated string literal")
+			}
+			i = end
+		case c == '"':
+			end, ok := identEnd(expr, i)
+			if !ok {
+				return errors.New("unterminated quoted identifier")
+			}
+			i = end
+		case c == '$' && (i == 0 || !isIdentChar(expr[i-1])):
+			tag := dollarQuotePattern.FindString(expr[i:])
+			if tag == "" {
+				continue
+			}
+			end := strings.Index(expr[i+len(tag):], tag)
+			if end < 0 {
+				return errors.New("unterminated dollar-quoted string")
+			}
+			i += 2*len(tag) + end - 1
+		case c == ';':
+			return errors.New("statement separators are not allowed")
+		case c == '-' && i+1 < len(expr) && expr[i+1] == '-',
+			c == '/' && i+1 < len(expr) && expr[i+1] == '*':
+			return errors.New("comments are not allowed")
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth < 0 {
+				return errors.New("unbalanced parentheses")
+			}
+		}
+	}
+	if depth != 0 {
+		return errors.New("unbalanced parentheses")
+	}
+
+	return nil
+}
+
+// stringEnd returns the position of the quote closing the string literal opened at
+// start. Backslashes escape characters only in E'' literals.
+func stringEnd(expr string, start int) (int, bool) {
+	escapes := start > 0 && (expr[start-1] == 'E' || expr[start-1] == 'e') &&
+		(start == 1 || !isIdentChar(expr[start-2]))
+	for i := start + 1; i < len(expr); i++ {
+		switch {
+		case escapes && expr[i] == '\\':
+			i++
+		case expr[i] == '\'':
+			if i+1 < len(expr) && expr[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// identEnd returns the position of the quote closing the identifier opened at start.
+func identEnd(expr string, start int) (int, bool) {
+	for i := start + 1; i < len(expr); i++ {
+		if expr[i] == '"' {
+			if i+1 < len(expr) && expr[i+1] == '"' {
+				i++
+				continue
+			}
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
@@ -0,0 +1,45 @@
+package pgclient
+
+import "testing"
+
+func TestCheckExpression(t *testing.T) {
+	valid := []string{
+		"owner = current_user",
+		"tenant_id = current_setting('app.tenant')::int",
+		"note <> 'a;b -- c'",
+		"note <> 'it''s'",
+		`"semi;colon" > 0`,
+		`"a""b" IS NOT NULL`,
+		"body <> $$;$$",
+		"body <> $tag$ ) -- $ $tag$",
+		"price $1",
+		"a$b > 0",
+		`note <> E'\';'`,
+		"lower(email) LIKE '%@example.com'",
+		"(a > 0) AND (b < 10 OR c IS NULL)",
+		"x - -1 > 0",
+	}
+	invalid := []string{
+		"true); DROP TABLE users; --",
+		"true; DROP TABLE users",
+		"true -- hide the rest",
+		"true /* hide */",
+		"x > 0)",
+		"(x > 0",
+		"'unterminated",
+		`"unterminated`,
+		"$$unterminated",
+		`note <> '\'; DROP TABLE users; --'`,
+	}
+
+	for _, expr := range valid {
+		if err := CheckExpression(expr); err != nil {
+			t.Errorf("%q rejected: %v", expr, err)
+		}
+	}
+	for _, expr := range invalid {
+		if err := CheckExpression(expr); err == nil {
+			t.Errorf("%q accepted, want rejected", expr)
+		}
+	}
+}
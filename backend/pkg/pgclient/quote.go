@@ -0,0 +1,23 @@
+package pgclient
+
+import "github.com/lib/pq"
+
+// QuoteIdent quotes an identifier so it can be embedded into generated SQL.
+// Mixed-case names, reserved words and embedded quotes are preserved as is.
+func QuoteIdent(name string) string {
+	return pq.QuoteIdentifier(name)
+}
+
+// QuoteQualified quotes a schema-qualified name. An empty schema leaves the name unqualified.
+func QuoteQualified(schema, name string) string {
+	if schema == "" {
+		return QuoteIdent(name)
+	}
+
+	return QuoteIdent(schema) + "." + QuoteIdent(name)
+}
+
+// QuoteLiteral quotes a string literal, using the E'' form when it contains backslashes.
+func QuoteLiteral(value string) string {
+	return pq.QuoteLiteral(value)
+}